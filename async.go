@@ -0,0 +1,79 @@
+package binding
+
+import (
+	"context"
+	"sync"
+)
+
+// Workers sets the number of field validators (see Field.Validator)
+// that may run at the same time, instead of ValidatorWorkers.
+func Workers(n int) Option {
+	return func(o *options) {
+		o.workers = n
+	}
+}
+
+// asyncCheck is a Field.Validator waiting to be run.
+type asyncCheck struct {
+	form     string
+	validate func(context.Context) error
+}
+
+// runValidators runs checks on at most workers goroutines at a time.
+// Errors are returned in the order of checks, whatever order the checks
+// finish in. If ctx is done before all checks finish, the checks that
+// have not started are skipped and a single CanceledError is returned.
+func runValidators(ctx context.Context, checks []asyncCheck, workers int) Errors {
+	var errs Errors
+
+	if len(checks) == 0 {
+		return nil
+	}
+	if workers < 1 {
+		workers = 1
+	}
+
+	results := make([]error, len(checks))
+	sem := make(chan struct{}, workers)
+	var wg sync.WaitGroup
+
+	for i, check := range checks {
+		wg.Add(1)
+		go func(i int, check asyncCheck) {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+			defer func() { <-sem }()
+			if ctx.Err() == nil {
+				results[i] = check.validate(ctx)
+			}
+		}(i, check)
+	}
+
+	finished := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(finished)
+	}()
+
+	select {
+	case <-finished:
+	case <-ctx.Done():
+	}
+	if err := ctx.Err(); err != nil {
+		errs.addCause([]string{}, CanceledError, err)
+		return errs
+	}
+
+	for i, err := range results {
+		if err == nil {
+			continue
+		}
+		errs.addErr([]string{checks[i].form}, err)
+	}
+
+	return errs
+}
@@ -0,0 +1,119 @@
+package binding
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type UniqueModel struct {
+	Names   [5]string
+	check   func(ctx context.Context, name string) error
+	context context.Context
+}
+
+func (m *UniqueModel) FieldMap(req *http.Request) FieldMap {
+	fm := FieldMap{}
+	for i, form := range []string{"e", "d", "c", "b", "a"} {
+		name := &m.Names[i]
+		fm[name] = Field{Form: form, Validator: func(ctx context.Context) error {
+			return m.check(ctx, *name)
+		}}
+	}
+	return fm
+}
+
+func (m *UniqueModel) ValidateContext(ctx context.Context, req *http.Request) error {
+	m.context = ctx
+	return nil
+}
+
+type requestIDKey struct{}
+
+type SignupName struct {
+	Name string
+}
+
+func (m *SignupName) FieldMap(req *http.Request) FieldMap {
+	return FieldMap{&m.Name: "name"}
+}
+
+func (m *SignupName) ValidateContext(ctx context.Context, req *http.Request) error {
+	return errors.New("username taken")
+}
+
+func TestFieldValidators(t *testing.T) {
+	Convey("Given a struct with field validators", t, func() {
+		req, err := http.NewRequest("POST", "http://www.example.com", nil)
+		So(err, ShouldBeNil)
+		req = req.WithContext(context.WithValue(req.Context(), requestIDKey{}, "42"))
+		model := &UniqueModel{Names: [5]string{"e", "d", "c", "b", "a"}}
+
+		Convey("They should run concurrently within the worker limit", func() {
+			var running, most int32
+			model.check = func(ctx context.Context, name string) error {
+				n := atomic.AddInt32(&running, 1)
+				defer atomic.AddInt32(&running, -1)
+				for {
+					m := atomic.LoadInt32(&most)
+					if n <= m || atomic.CompareAndSwapInt32(&most, m, n) {
+						break
+					}
+				}
+				time.Sleep(10 * time.Millisecond)
+				return errors.New(name + " is taken")
+			}
+
+			err := Validate(req, model, Workers(2))
+			So(atomic.LoadInt32(&most), ShouldEqual, 2)
+
+			Convey("And their errors should be ordered by field name", func() {
+				errs, _ := err.(Errors)
+				So(errs.Len(), ShouldEqual, 5)
+				for i, e := range errs {
+					So(e.Fields(), ShouldResemble, []string{string(rune('a' + i))})
+				}
+			})
+
+			Convey("And the ContextValidator should receive the request's context", func() {
+				So(model.context, ShouldNotBeNil)
+				So(model.context.Value(requestIDKey{}), ShouldEqual, "42")
+			})
+		})
+
+		Convey("Cancelling the request should stop them promptly", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			model.check = func(ctx context.Context, name string) error {
+				cancel()
+				<-ctx.Done()
+				return ctx.Err()
+			}
+
+			err := Validate(req.WithContext(ctx), model, Workers(1))
+			errs, _ := err.(Errors)
+			So(errs.Len(), ShouldEqual, 1)
+			So(errs.Has(CanceledError), ShouldBeTrue)
+			So(model.context, ShouldBeNil)
+		})
+	})
+
+	Convey("Given a ContextValidator and a canceled request", t, func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		req, err := http.NewRequestWithContext(ctx, "GET", "http://www.example.com/?name=a", nil)
+		So(err, ShouldBeNil)
+		err = Bind(req, new(SignupName))
+
+		Convey("Binding should fail with a CanceledError instead of skipping it", func() {
+			errs, _ := err.(Errors)
+			So(errs.Len(), ShouldEqual, 1)
+			So(errs.Has(CanceledError), ShouldBeTrue)
+			So(errors.Is(err, context.Canceled), ShouldBeTrue)
+		})
+	})
+}
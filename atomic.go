@@ -0,0 +1,228 @@
+package binding
+
+import (
+	"mime/multipart"
+	"net/http"
+	"reflect"
+	"time"
+)
+
+// Atomic makes binding all-or-nothing: if any field fails to convert
+// or the struct fails validation, the struct is restored to the value
+// it had before binding, so that a struct pre-populated from a database
+// is left unchanged by a failed request. This covers every exported
+// field, whether it is in the FieldMap or not, and the values its
+// pointers, slices, maps and interfaces lead to, which is all that
+// encoding/json can change.
+//
+// What a Binder, a Field.Binder func or an UnmarshalJSON method changes
+// outside the struct, or behind unexported fields, is not restored.
+func Atomic() Option {
+	return func(o *options) {
+		o.atomic = true
+	}
+}
+
+// snapshot records the current values of the fields of userStruct and
+// returns a function that restores them.
+func snapshot(req *http.Request, userStruct FieldMapper) (restore func()) {
+	var restores []func()
+
+	for _, fieldSpec := range fieldList(req, userStruct).fields() {
+		if r := keepField(fieldSpec.Target); r != nil {
+			restores = append(restores, r)
+		}
+	}
+	if v := reflect.ValueOf(userStruct); v.Kind() == reflect.Ptr && !v.IsNil() {
+		restores = append(restores, keepValue(v.Elem(), make(map[uintptr]bool)))
+	}
+
+	return func() {
+		for _, r := range restores {
+			r()
+		}
+	}
+}
+
+// keepField records the value that fieldPointer points to and returns
+// a function that restores it, or nil if the type is not supported.
+func keepField(fieldPointer interface{}) func() {
+	switch t := fieldPointer.(type) {
+	case *uint8:
+		return keep(t)
+	case **uint8:
+		return keep(t)
+	case *[]uint8:
+		return keep(t)
+	case *uint16:
+		return keep(t)
+	case **uint16:
+		return keep(t)
+	case *[]uint16:
+		return keep(t)
+	case *uint32:
+		return keep(t)
+	case **uint32:
+		return keep(t)
+	case *[]uint32:
+		return keep(t)
+	case *uint64:
+		return keep(t)
+	case **uint64:
+		return keep(t)
+	case *[]uint64:
+		return keep(t)
+	case *int8:
+		return keep(t)
+	case **int8:
+		return keep(t)
+	case *[]int8:
+		return keep(t)
+	case *int16:
+		return keep(t)
+	case **int16:
+		return keep(t)
+	case *[]int16:
+		return keep(t)
+	case *int32:
+		return keep(t)
+	case **int32:
+		return keep(t)
+	case *[]int32:
+		return keep(t)
+	case *int64:
+		return keep(t)
+	case **int64:
+		return keep(t)
+	case *[]int64:
+		return keep(t)
+	case *float32:
+		return keep(t)
+	case **float32:
+		return keep(t)
+	case *[]float32:
+		return keep(t)
+	case *float64:
+		return keep(t)
+	case **float64:
+		return keep(t)
+	case *[]float64:
+		return keep(t)
+	case *uint:
+		return keep(t)
+	case **uint:
+		return keep(t)
+	case *[]uint:
+		return keep(t)
+	case *int:
+		return keep(t)
+	case **int:
+		return keep(t)
+	case *[]int:
+		return keep(t)
+	case *bool:
+		return keep(t)
+	case **bool:
+		return keep(t)
+	case *[]bool:
+		return keep(t)
+	case *string:
+		return keep(t)
+	case **string:
+		return keep(t)
+	case *[]string:
+		return keep(t)
+	case *time.Time:
+		return keep(t)
+	case **time.Time:
+		return keep(t)
+	case *[]time.Time:
+		return keep(t)
+	case **multipart.FileHeader:
+		return keep(t)
+	case *[]*multipart.FileHeader:
+		return keep(t)
+	case optionalValue:
+		return t.snapshot()
+	}
+	return nil
+}
+
+func keep[T any](p *T) func() {
+	v := *p
+	return func() {
+		*p = v
+	}
+}
+
+// keepValue records v, which must be settable, along with the values
+// that its exported fields, pointers, slices, maps and interfaces lead
+// to, and returns a function that restores them. Pointers in seen are
+// already recorded.
+func keepValue(v reflect.Value, seen map[uintptr]bool) func() {
+	saved := reflect.New(v.Type()).Elem()
+	saved.Set(v)
+	var restores []func()
+	keepInner := func(inner reflect.Value) {
+		restores = append(restores, keepValue(inner, seen))
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				keepInner(v.Field(i))
+			}
+		}
+	case reflect.Ptr:
+		if !v.IsNil() && !seen[v.Pointer()] {
+			seen[v.Pointer()] = true
+			keepInner(v.Elem())
+		}
+	case reflect.Interface:
+		if e := v.Elem(); e.Kind() == reflect.Ptr && !e.IsNil() && !seen[e.Pointer()] {
+			seen[e.Pointer()] = true
+			keepInner(e.Elem())
+		}
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			keepInner(v.Index(i))
+		}
+	case reflect.Slice:
+		// Decoding reuses the backing array, up to its capacity.
+		all := v.Slice(0, v.Cap())
+		elems := reflect.MakeSlice(v.Type(), all.Len(), all.Len())
+		reflect.Copy(elems, all)
+		restores = append(restores, func() {
+			reflect.Copy(all, elems)
+		})
+		for i := 0; i < all.Len(); i++ {
+			switch all.Index(i).Kind() {
+			case reflect.Struct, reflect.Ptr, reflect.Interface, reflect.Array, reflect.Slice, reflect.Map:
+				keepInner(all.Index(i))
+			}
+		}
+	case reflect.Map:
+		if !v.IsNil() {
+			entries := reflect.MakeMapWithSize(v.Type(), v.Len())
+			iter := v.MapRange()
+			for iter.Next() {
+				entries.SetMapIndex(iter.Key(), iter.Value())
+			}
+			restores = append(restores, func() {
+				v.Clear()
+				iter := entries.MapRange()
+				for iter.Next() {
+					v.SetMapIndex(iter.Key(), iter.Value())
+				}
+			})
+		}
+	}
+
+	return func() {
+		v.Set(saved)
+		for _, r := range restores {
+			r()
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package binding
+
+import (
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type AccountForm struct {
+	Name  string
+	Extra string
+	Tags  []string
+	Meta  map[string]string
+}
+
+func (f *AccountForm) FieldMap(req *http.Request) FieldMap {
+	return FieldMap{
+		&f.Name: Field{Form: "name", Required: true},
+	}
+}
+
+func TestAtomic(t *testing.T) {
+	Convey("Given a struct populated with existing values", t, func() {
+		original := NewCompleteModel()
+		model := original
+
+		Convey("When a form with one invalid field is bound atomically", func() {
+			data := url.Values{}
+			data.Set("string", "changed")
+			data.Set("intSlice", "7")
+			data.Set("uint8", "256")
+			req, err := http.NewRequest("POST", "http://www.example.com", strings.NewReader(data.Encode()))
+			So(err, ShouldBeNil)
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+			err = Bind(req, &model, Atomic())
+			So(err, ShouldNotBeNil)
+
+			Convey("The struct should be left unchanged", func() {
+				So(reflect.DeepEqual(model, original), ShouldBeTrue)
+			})
+		})
+
+		Convey("When a JSON body fails validation and is bound atomically", func() {
+			req, err := http.NewRequest("POST", "http://www.example.com", strings.NewReader(`{"String": ""}`))
+			So(err, ShouldBeNil)
+			req.Header.Set("Content-Type", "application/json")
+
+			err = Bind(req, &model, Atomic())
+			So(err, ShouldNotBeNil)
+
+			Convey("The struct should be left unchanged", func() {
+				So(reflect.DeepEqual(model, original), ShouldBeTrue)
+			})
+		})
+
+		Convey("When a JSON body with a value of the wrong type is bound atomically", func() {
+			req, err := http.NewRequest("POST", "http://www.example.com", strings.NewReader(`{"String": "changed", "Int": "x"}`))
+			So(err, ShouldBeNil)
+			req.Header.Set("Content-Type", "application/json")
+
+			err = Bind(req, &model, Atomic())
+			So(err, ShouldNotBeNil)
+
+			Convey("The struct should be left unchanged", func() {
+				So(model.String, ShouldEqual, original.String)
+				So(reflect.DeepEqual(model, original), ShouldBeTrue)
+			})
+		})
+
+		Convey("When a JSON body changes fields that are not in the FieldMap", func() {
+			profile := AccountForm{Name: "a", Extra: "kept", Tags: []string{"x", "y"}, Meta: map[string]string{"k": "v"}}
+			req, err := http.NewRequest("POST", "http://www.example.com",
+				strings.NewReader(`{"extra": "changed", "name": "", "tags": ["z"], "meta": {"k": "w", "n": "m"}}`))
+			So(err, ShouldBeNil)
+			req.Header.Set("Content-Type", "application/json")
+
+			err = Bind(req, &profile, Atomic())
+			So(err, ShouldNotBeNil)
+
+			Convey("They should be restored too", func() {
+				So(profile, ShouldResemble, AccountForm{Name: "a", Extra: "kept", Tags: []string{"x", "y"}, Meta: map[string]string{"k": "v"}})
+			})
+		})
+
+		Convey("When a form with one invalid field is bound without Atomic", func() {
+			req, err := http.NewRequest("POST", "http://www.example.com", nil)
+			So(err, ShouldBeNil)
+
+			errs := bindForm(req, &model, map[string][]string{"uint8": {"256"}, "intSlice": {"1", "x"}}, nil)
+			So(errs.Has(TypeError), ShouldBeTrue)
+
+			Convey("The invalid fields should not be overwritten", func() {
+				So(model.Uint8, ShouldEqual, original.Uint8)
+				So(model.IntSlice, ShouldResemble, original.IntSlice)
+			})
+		})
+	})
+}
@@ -4,11 +4,14 @@
 package binding
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"io"
 	"mime/multipart"
 	"net/http"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
@@ -21,48 +24,41 @@ type requestBinder func(req *http.Request, userStruct FieldMapper) Errors
 // better be data in the query string, otherwise an error will be produced.
 //
 // A non-nil return value may be an Errors value.
-func Bind(req *http.Request, userStruct FieldMapper) error {
-	var errs Errors
-
+func Bind(req *http.Request, userStruct FieldMapper, opts ...Option) error {
 	contentType := req.Header.Get("Content-Type")
 
 	if strings.Contains(contentType, "form-urlencoded") {
-		return Form(req, userStruct)
+		return Form(req, userStruct, opts...)
 	}
 
 	if strings.Contains(contentType, "multipart/form-data") {
-		return MultipartForm(req, userStruct)
+		return MultipartForm(req, userStruct, opts...)
 	}
 
 	if strings.Contains(contentType, "json") {
-		return Json(req, userStruct)
+		return Json(req, userStruct, opts...)
 	}
 
 	if req.Method == http.MethodGet || req.Method == http.MethodHead || (contentType == "" && len(req.URL.Query()) > 0) {
-		return URL(req, userStruct)
+		return URL(req, userStruct, opts...)
 	}
 
-	if contentType == "" {
-		errs.Add([]string{}, ContentTypeError, "Empty Content-Type")
-		errs = validate(errs, req, userStruct)
-	} else {
-		errs.Add([]string{}, ContentTypeError, "Unsupported Content-Type")
-	}
-
-	if len(errs) > 0 {
+	return run(func(req *http.Request, userStruct FieldMapper) Errors {
+		var errs Errors
+		if contentType == "" {
+			errs.addTemplate([]string{}, ContentTypeError, "EmptyContentType", "Empty Content-Type", nil)
+			errs = validate(errs, req, userStruct)
+		} else {
+			errs.addTemplate([]string{}, ContentTypeError, ContentTypeError, "Unsupported Content-Type", nil)
+		}
 		return errs
-	}
-	return nil
+	}, req, userStruct, opts)
 }
 
 // Form deserializes form data out of the request into a struct you provide.
 // This function invokes data validation after deserialization.
-func Form(req *http.Request, userStruct FieldMapper) error {
-	err := formBinder(req, userStruct)
-	if len(err) > 0 {
-		return err
-	}
-	return nil
+func Form(req *http.Request, userStruct FieldMapper, opts ...Option) error {
+	return run(formBinder, req, userStruct, opts)
 }
 
 var formBinder requestBinder = defaultFormBinder
@@ -72,7 +68,7 @@ func defaultFormBinder(req *http.Request, userStruct FieldMapper) Errors {
 
 	parseErr := req.ParseForm()
 	if parseErr != nil {
-		errs.Add([]string{}, DeserializationError, parseErr.Error())
+		errs.addCause([]string{}, readErrorKind(parseErr), parseErr)
 		return errs
 	}
 
@@ -81,13 +77,8 @@ func defaultFormBinder(req *http.Request, userStruct FieldMapper) Errors {
 
 // URL reads data out of the query string into a struct you provide.
 // This function invokes data validation after deserialization.
-func URL(req *http.Request, userStruct FieldMapper) error {
-	err := urlBinder(req, userStruct)
-	if len(err) > 0 {
-		return err
-	}
-	return nil
-
+func URL(req *http.Request, userStruct FieldMapper, opts ...Option) error {
+	return run(urlBinder, req, userStruct, opts)
 }
 
 var urlBinder requestBinder = defaultURLBinder
@@ -99,13 +90,8 @@ func defaultURLBinder(req *http.Request, userStruct FieldMapper) Errors {
 // MultipartForm reads a multipart form request and deserializes its data and
 // files into a struct you provide. Files should be deserialized into
 // *multipart.FileHeader fields.
-func MultipartForm(req *http.Request, userStruct FieldMapper) error {
-	err := multipartFormBinder(req, userStruct)
-	if len(err) > 0 {
-		return err
-	}
-
-	return nil
+func MultipartForm(req *http.Request, userStruct FieldMapper, opts ...Option) error {
+	return run(multipartFormBinder, req, userStruct, opts)
 }
 
 var multipartFormBinder requestBinder = defaultMultipartFormBinder
@@ -115,13 +101,13 @@ func defaultMultipartFormBinder(req *http.Request, userStruct FieldMapper) Error
 
 	multipartReader, err := req.MultipartReader()
 	if err != nil {
-		errs.Add([]string{}, DeserializationError, err.Error())
+		errs.addCause([]string{}, DeserializationError, err)
 		return errs
 	}
 
 	form, parseErr := multipartReader.ReadForm(MaxMemory)
 	if parseErr != nil {
-		errs.Add([]string{}, DeserializationError, parseErr.Error())
+		errs.addCause([]string{}, readErrorKind(parseErr), parseErr)
 		return errs
 	}
 
@@ -133,13 +119,8 @@ func defaultMultipartFormBinder(req *http.Request, userStruct FieldMapper) Error
 // Json deserializes a JSON request body into a struct you specify
 // using the standard encoding/json package (which uses reflection).
 // This function invokes data validation after deserialization.
-func Json(req *http.Request, userStruct FieldMapper) error {
-	err := jsonBinder(req, userStruct)
-	if len(err) > 0 {
-		return err
-	}
-
-	return nil
+func Json(req *http.Request, userStruct FieldMapper, opts ...Option) error {
+	return run(jsonBinder, req, userStruct, opts)
 }
 
 var jsonBinder requestBinder = defaultJsonBinder
@@ -147,56 +128,87 @@ var jsonBinder requestBinder = defaultJsonBinder
 func defaultJsonBinder(req *http.Request, userStruct FieldMapper) Errors {
 	var errs Errors
 
-	if req.Body != nil {
-		defer req.Body.Close()
-		err := json.NewDecoder(req.Body).Decode(userStruct)
-		if err != nil && err != io.EOF {
-			errs.Add([]string{}, DeserializationError, err.Error())
-			return errs
-		}
-	} else {
-		errs.Add([]string{}, DeserializationError, "Empty request body")
+	if req.Body == nil {
+		errs.addTemplate([]string{}, DeserializationError, "EmptyBody", "Empty request body", nil)
 		return errs
 	}
+	defer req.Body.Close()
 
-	errs = validate(errs, req, userStruct)
-	if len(errs) > 0 {
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		errs.addCause([]string{}, readErrorKind(err), err)
 		return errs
 	}
 
-	return nil
+	req, st := withState(req)
+	fl := fieldList(req, userStruct)
+	names := jsonKeysOf(userStruct, fl)
+	st.recordJSON(body, names)
+
+	if st.atomic {
+		restore := snapshot(req, userStruct)
+		defer func() {
+			if len(errs) > 0 {
+				restore()
+			}
+		}()
+	}
+
+	if st.strict {
+		var unknown Errors
+		body, unknown = st.strictJSON(body, fl, names)
+		errs = append(errs, unknown...)
+	}
+
+	errs = applyDefaults(errs, fl)
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	if st.strict {
+		decoder.DisallowUnknownFields()
+	}
+	err = decoder.Decode(userStruct)
+	if err != nil && err != io.EOF {
+		errs = append(errs, jsonError(req, err, body, userStruct))
+		return errs
+	}
+
+	decoded := len(errs)
+	errs = normalize(errs, st, fl, true)
+	errs = validate(errs, req, userStruct)
+	withJSONPaths(errs[decoded:], names)
+	return errs
 }
 
 // Validate ensures that all conditions have been met on every field in the
 // populated struct. Validation should occur after the request has been
 // deserialized into the struct.
-func Validate(req *http.Request, userStruct FieldMapper) error {
-	err := validate(Errors{}, req, userStruct)
-	if len(err) > 0 {
-		return err
-	}
-
-	return nil
+func Validate(req *http.Request, userStruct FieldMapper, opts ...Option) error {
+	return run(func(req *http.Request, userStruct FieldMapper) Errors {
+		return validate(Errors{}, req, userStruct)
+	}, req, userStruct, opts)
 }
 
 func validate(errs Errors, req *http.Request, userStruct FieldMapper) Errors {
-	fm := userStruct.FieldMap(req)
-
-	for fieldPointer, fieldNameOrSpec := range fm {
-		fieldSpec, err := fieldSpecification(fieldNameOrSpec)
-		if err != nil {
+	req, st := withState(req)
+	fl := fieldList(req, userStruct)
+	cf := newCrossFields(fl)
+	var checks []asyncCheck
+
+	for _, fieldSpec := range fl.fields() {
+		fieldPointer := fieldSpec.Target
+		if !st.applies(fieldSpec.Groups) {
 			continue
 		}
 
 		addRequiredError := func() {
-			errorMsg := "Required"
-			if len(fieldSpec.ErrorMessage) > 0 {
-				errorMsg = fieldSpec.ErrorMessage
-			}
-
-			errs.Add([]string{fieldSpec.Form}, RequiredError, errorMsg)
+			fieldSpec.addError(&errs, []string{fieldSpec.Form}, RequiredError, RequiredError, "Required", nil)
 		}
-		if fieldSpec.Required {
+		if fieldSpec.Required && st.requirePresence && st.fields != nil {
+			// A required Optional must not be null either.
+			if o, ok := fieldPointer.(optionalValue); !st.fields.Has(fieldSpec.Form) || (ok && o.missing()) {
+				addRequiredError()
+			}
+		} else if fieldSpec.Required {
 			switch t := fieldPointer.(type) {
 			case *uint8:
 				if *t == 0 {
@@ -382,25 +394,80 @@ func validate(errs Errors, req *http.Request, userStruct FieldMapper) Errors {
 				if *t == nil {
 					addRequiredError()
 				}
+			case optionalValue:
+				if t.missing() {
+					addRequiredError()
+				}
+			case *[]*multipart.FileHeader:
+				if len(*t) == 0 {
+					addRequiredError()
+				}
+			case Nested:
+				if len(t.mappers()) == 0 {
+					addRequiredError()
+				}
+			case Zeroer:
+				if t.IsZero() {
+					addRequiredError()
+				}
+			default:
+				// The zero value of other types is unknown, so go by
+				// whether the field was in the request, if there is one.
+				if st.fields != nil && !st.fields.Has(fieldSpec.Form) {
+					addRequiredError()
+				}
 			}
 		}
+
+		// Constraints only apply to fields that were sent, and that
+		// did not already fail to bind or pass the Required check.
+		if (st.fields == nil || st.fields.Has(fieldSpec.Form)) && !errs.hasField(fieldSpec.Form) {
+			errs = append(errs, checkConstraints(fieldPointer, fieldSpec)...)
+		}
+
+		if !errs.hasField(fieldSpec.Form) {
+			errs = append(errs, checkCrossField(fieldPointer, fieldSpec, cf)...)
+		}
+
+		// Nested values are validated unless a JSON body left them out.
+		// Form requests don't bind them, so they are always validated.
+		if !st.json || st.fields.Has(fieldSpec.Form) {
+			errs = append(errs, validateNested(req, st, fieldPointer, fieldSpec.Form)...)
+		}
+
+		if (st.fields == nil || st.fields.Has(fieldSpec.Form)) && fieldSpec.Validator != nil && !errs.hasField(fieldSpec.Form) {
+			checks = append(checks, asyncCheck{fieldSpec.Form, fieldSpec.Validator})
+		}
 	}
 
-	if validator, ok := userStruct.(Validator); ok {
-		err := validator.Validate(req)
-		if err != nil {
-			switch e := err.(type) {
-			case Error:
-				errs = append(errs, e)
-			case Errors:
-				errs = append(errs, e...)
-			default:
-				errs.Add([]string{}, "", e.Error())
-			}
+	for _, rule := range fl.rules() {
+		if st.applies(rule.groups) {
+			errs = append(errs, rule.check(cf)...)
 		}
 	}
 
+	workers := ValidatorWorkers
+	if st.workers > 0 {
+		workers = st.workers
+	}
+	errs = append(errs, runValidators(req.Context(), checks, workers)...)
+
+	var err error
+	if validator, ok := userStruct.(ContextValidator); ok {
+		if ctxErr := req.Context().Err(); ctxErr == nil {
+			err = validator.ValidateContext(req.Context(), req)
+		} else if !errs.Has(CanceledError) {
+			errs.addCause([]string{}, CanceledError, ctxErr)
+		}
+	} else if validator, ok := userStruct.(Validator); ok {
+		err = validator.Validate(req)
+	}
+	if err != nil {
+		errs.addErr([]string{}, err)
+	}
+
 	if len(errs) > 0 {
+		sortErrors(errs, fl)
 		return errs
 	}
 
@@ -412,347 +479,566 @@ func bindForm(req *http.Request, userStruct FieldMapper, formData map[string][]s
 
 	var errs Errors
 
-	fm := userStruct.FieldMap(req)
+	req, st := withState(req)
+	st.recordForm(formData, formFile)
 
-	for fieldPointer, fieldNameOrSpec := range fm {
-		fieldSpec, err := fieldSpecification(fieldNameOrSpec)
-		if err != nil {
+	if st.atomic {
+		restore := snapshot(req, userStruct)
+		defer func() {
+			if len(errs) > 0 {
+				restore()
+			}
+		}()
+	}
+
+	fl := fieldList(req, userStruct)
+
+	if st.strict {
+		keys := make([]string, 0, len(formData)+len(formFile))
+		for key := range formData {
+			keys = append(keys, key)
+		}
+		for key := range formFile {
+			keys = append(keys, key)
+		}
+		errs = append(errs, st.unknownFields(keys, fl)...)
+	}
+
+	for _, fieldSpec := range fl.fields() {
+		strs := fieldSpec.transform(formData[fieldSpec.Form])
+		files := formFile[fieldSpec.Form]
+		if len(strs) == 0 && len(files) == 0 && fieldSpec.Default != "" {
+			errs = append(errs, bindDefault(fieldSpec)...)
 			continue
 		}
 
-		strs := formData[fieldSpec.Form]
-		_, isFile := fieldPointer.(**multipart.FileHeader)
-		_, isFileSlice := fieldPointer.(*[]*multipart.FileHeader)
+		if st.strict && fieldSpec.MultiValue == MultiValueDefault {
+			fieldSpec.MultiValue = MultiValueError
+		}
 
-		if !isFile && !isFileSlice {
-			if fieldSpec.Binder != nil {
-				err := fieldSpec.Binder(fieldSpec.Form, strs)
-				if err != nil {
-					switch e := err.(type) {
-					case Error:
-						errs = append(errs, e)
-					case Errors:
-						errs = append(errs, e...)
-					default:
-						errs.Add([]string{fieldSpec.Form}, "", e.Error())
-					}
-				}
-				continue
-			}
+		errs = append(errs, bindField(fieldSpec.Target, fieldSpec, strs, files)...)
+	}
 
-			if binder, ok := fieldPointer.(Binder); ok {
-				err := binder.Bind(fieldSpec.Form, strs)
-				if err != nil {
-					switch e := err.(type) {
-					case Error:
-						errs = append(errs, e)
-					case Errors:
-						errs = append(errs, e...)
-					default:
-						errs.Add([]string{fieldSpec.Form}, "", e.Error())
-					}
-				}
-				continue
-			}
+	errs = normalize(errs, st, fl, false)
+	errs = validate(errs, req, userStruct)
+	return errs
+}
 
-			if len(strs) == 0 {
-				continue
+// bindField stores strs (or files, for file fields) in the field that
+// fieldPointer points to, using the field's Binder if it has one.
+func bindField(fieldPointer interface{}, fieldSpec Field, strs []string, files []*multipart.FileHeader) Errors {
+	var errs Errors
+
+	if opt, ok := fieldPointer.(optionalValue); ok {
+		return opt.bindForm(fieldSpec, strs, files)
+	}
+
+	_, isFile := fieldPointer.(**multipart.FileHeader)
+	_, isFileSlice := fieldPointer.(*[]*multipart.FileHeader)
+
+	if !isFile && !isFileSlice {
+		if fieldSpec.Binder != nil {
+			err := fieldSpec.Binder(fieldSpec.Form, strs)
+			if err != nil {
+				errs.addErr([]string{fieldSpec.Form}, err)
 			}
+			return errs
 		}
 
-		errorHandler := func(err error) {
+		if binder, ok := fieldPointer.(Binder); ok {
+			err := binder.Bind(fieldSpec.Form, strs)
 			if err != nil {
-				errs.Add([]string{fieldSpec.Form}, TypeError, err.Error())
+				errs.addErr([]string{fieldSpec.Form}, err)
 			}
+			return errs
 		}
 
-		switch t := fieldPointer.(type) {
-		case *uint8:
-			val, err := strconv.ParseUint(strs[0], 10, 8)
+		// Nested FieldMappers are not bound from forms.
+		_, isNested := fieldPointer.(Nested)
+		_, isMapper := fieldPointer.(FieldMapper)
+
+		if isNested || isMapper || len(strs) == 0 {
+			return nil
+		}
+	}
+
+	return bindValue(fieldPointer, fieldSpec, strs, files)
+}
+
+// bindValue converts strs (or files, for file fields) to the type of
+// fieldPointer and stores the result in it.
+func bindValue(fieldPointer interface{}, fieldSpec Field, strs []string, files []*multipart.FileHeader) Errors {
+	var errs Errors
+
+	errorHandler := func(err error) {
+		if err == nil {
+			return
+		}
+		params := map[string]interface{}{"type": typeName(fieldPointer)}
+		if value, ok := rejectedValue(err); ok {
+			params["value"] = value
+		}
+		message := err.Error()
+		if fieldSpec.Redact {
+			// The messages of conversion errors quote the value.
+			message = "Must be a valid " + typeName(fieldPointer)
+		}
+
+		kind := TypeError
+		var rangeErr *rangeError
+		if errors.As(err, &rangeErr) {
+			kind = RangeError
+			params["min"], params["max"] = json.Number(rangeErr.min), json.Number(rangeErr.max)
+		}
+		e := fieldSpec.newError([]string{fieldSpec.Form}, kind, kind, message, params)
+		errs = append(errs, e.withCause(err))
+	}
+
+	if !isSlice(fieldPointer) {
+		var multiErrs Errors
+		strs, files, multiErrs = single(fieldSpec, strs, files)
+		if len(multiErrs) > 0 {
+			return multiErrs
+		}
+	}
+
+	switch t := fieldPointer.(type) {
+	case *uint8:
+		val, err := parseUint(strs[0], 8, fieldSpec)
+		if err != nil {
 			errorHandler(err)
-			*t = uint8(val)
-		case **uint8:
-			parsed, err := strconv.ParseUint(strs[0], 10, 8)
+			return errs
+		}
+		*t = uint8(val)
+	case **uint8:
+		parsed, err := parseUint(strs[0], 8, fieldSpec)
+		if err != nil {
+			errorHandler(err)
+			return errs
+		}
+		val := uint8(parsed)
+		*t = &val
+	case *[]uint8:
+		vals := make([]uint8, 0, len(strs))
+		for _, str := range strs {
+			val, err := parseUint(str, 8, fieldSpec)
 			if err != nil {
 				errorHandler(err)
 				continue
 			}
-			val := uint8(parsed)
-			*t = &val
-		case *[]uint8:
-			for _, str := range strs {
-				val, err := strconv.ParseUint(str, 10, 8)
-				errorHandler(err)
-				*t = append(*t, uint8(val))
-			}
-		case *uint16:
-			val, err := strconv.ParseUint(strs[0], 10, 16)
+			vals = append(vals, uint8(val))
+		}
+		if len(errs) > 0 {
+			return errs
+		}
+		*t = merge(*t, vals, fieldSpec.Merge)
+	case *uint16:
+		val, err := parseUint(strs[0], 16, fieldSpec)
+		if err != nil {
+			errorHandler(err)
+			return errs
+		}
+		*t = uint16(val)
+	case **uint16:
+		parsed, err := parseUint(strs[0], 16, fieldSpec)
+		if err != nil {
 			errorHandler(err)
-			*t = uint16(val)
-		case **uint16:
-			parsed, err := strconv.ParseUint(strs[0], 10, 16)
+			return errs
+		}
+		val := uint16(parsed)
+		*t = &val
+	case *[]uint16:
+		vals := make([]uint16, 0, len(strs))
+		for _, str := range strs {
+			val, err := parseUint(str, 16, fieldSpec)
 			if err != nil {
 				errorHandler(err)
 				continue
 			}
-			val := uint16(parsed)
-			*t = &val
-		case *[]uint16:
-			for _, str := range strs {
-				val, err := strconv.ParseUint(str, 10, 16)
-				errorHandler(err)
-				*t = append(*t, uint16(val))
-			}
-		case *uint32:
-			val, err := strconv.ParseUint(strs[0], 10, 32)
+			vals = append(vals, uint16(val))
+		}
+		if len(errs) > 0 {
+			return errs
+		}
+		*t = merge(*t, vals, fieldSpec.Merge)
+	case *uint32:
+		val, err := parseUint(strs[0], 32, fieldSpec)
+		if err != nil {
 			errorHandler(err)
-			*t = uint32(val)
-		case **uint32:
-			parsed, err := strconv.ParseUint(strs[0], 10, 32)
+			return errs
+		}
+		*t = uint32(val)
+	case **uint32:
+		parsed, err := parseUint(strs[0], 32, fieldSpec)
+		if err != nil {
+			errorHandler(err)
+			return errs
+		}
+		val := uint32(parsed)
+		*t = &val
+	case *[]uint32:
+		vals := make([]uint32, 0, len(strs))
+		for _, str := range strs {
+			val, err := parseUint(str, 32, fieldSpec)
 			if err != nil {
 				errorHandler(err)
 				continue
 			}
-			val := uint32(parsed)
-			*t = &val
-		case *[]uint32:
-			for _, str := range strs {
-				val, err := strconv.ParseUint(str, 10, 32)
-				errorHandler(err)
-				*t = append(*t, uint32(val))
-			}
-		case *uint64:
-			val, err := strconv.ParseUint(strs[0], 10, 64)
+			vals = append(vals, uint32(val))
+		}
+		if len(errs) > 0 {
+			return errs
+		}
+		*t = merge(*t, vals, fieldSpec.Merge)
+	case *uint64:
+		val, err := parseUint(strs[0], 64, fieldSpec)
+		if err != nil {
+			errorHandler(err)
+			return errs
+		}
+		*t = val
+	case **uint64:
+		parsed, err := parseUint(strs[0], 64, fieldSpec)
+		if err != nil {
 			errorHandler(err)
-			*t = val
-		case **uint64:
-			parsed, err := strconv.ParseUint(strs[0], 10, 64)
+			return errs
+		}
+		val := uint64(parsed)
+		*t = &val
+	case *[]uint64:
+		vals := make([]uint64, 0, len(strs))
+		for _, str := range strs {
+			val, err := parseUint(str, 64, fieldSpec)
 			if err != nil {
 				errorHandler(err)
 				continue
 			}
-			val := uint64(parsed)
-			*t = &val
-		case *[]uint64:
-			for _, str := range strs {
-				val, err := strconv.ParseUint(str, 10, 64)
-				errorHandler(err)
-				*t = append(*t, uint64(val))
-			}
-		case *int8:
-			val, err := strconv.ParseInt(strs[0], 10, 8)
+			vals = append(vals, uint64(val))
+		}
+		if len(errs) > 0 {
+			return errs
+		}
+		*t = merge(*t, vals, fieldSpec.Merge)
+	case *int8:
+		val, err := parseInt(strs[0], 8, fieldSpec)
+		if err != nil {
 			errorHandler(err)
-			*t = int8(val)
-		case **int8:
-			parsed, err := strconv.ParseInt(strs[0], 10, 8)
+			return errs
+		}
+		*t = int8(val)
+	case **int8:
+		parsed, err := parseInt(strs[0], 8, fieldSpec)
+		if err != nil {
+			errorHandler(err)
+			return errs
+		}
+		val := int8(parsed)
+		*t = &val
+	case *[]int8:
+		vals := make([]int8, 0, len(strs))
+		for _, str := range strs {
+			val, err := parseInt(str, 8, fieldSpec)
 			if err != nil {
 				errorHandler(err)
 				continue
 			}
-			val := int8(parsed)
-			*t = &val
-		case *[]int8:
-			for _, str := range strs {
-				val, err := strconv.ParseInt(str, 10, 8)
-				errorHandler(err)
-				*t = append(*t, int8(val))
-			}
-		case *int16:
-			val, err := strconv.ParseInt(strs[0], 10, 16)
+			vals = append(vals, int8(val))
+		}
+		if len(errs) > 0 {
+			return errs
+		}
+		*t = merge(*t, vals, fieldSpec.Merge)
+	case *int16:
+		val, err := parseInt(strs[0], 16, fieldSpec)
+		if err != nil {
 			errorHandler(err)
-			*t = int16(val)
-		case **int16:
-			parsed, err := strconv.ParseInt(strs[0], 10, 16)
+			return errs
+		}
+		*t = int16(val)
+	case **int16:
+		parsed, err := parseInt(strs[0], 16, fieldSpec)
+		if err != nil {
+			errorHandler(err)
+			return errs
+		}
+		val := int16(parsed)
+		*t = &val
+	case *[]int16:
+		vals := make([]int16, 0, len(strs))
+		for _, str := range strs {
+			val, err := parseInt(str, 16, fieldSpec)
 			if err != nil {
 				errorHandler(err)
 				continue
 			}
-			val := int16(parsed)
-			*t = &val
-		case *[]int16:
-			for _, str := range strs {
-				val, err := strconv.ParseInt(str, 10, 16)
-				errorHandler(err)
-				*t = append(*t, int16(val))
-			}
-		case *int32:
-			val, err := strconv.ParseInt(strs[0], 10, 32)
+			vals = append(vals, int16(val))
+		}
+		if len(errs) > 0 {
+			return errs
+		}
+		*t = merge(*t, vals, fieldSpec.Merge)
+	case *int32:
+		val, err := parseInt(strs[0], 32, fieldSpec)
+		if err != nil {
 			errorHandler(err)
-			*t = int32(val)
-		case **int32:
-			parsed, err := strconv.ParseInt(strs[0], 10, 32)
+			return errs
+		}
+		*t = int32(val)
+	case **int32:
+		parsed, err := parseInt(strs[0], 32, fieldSpec)
+		if err != nil {
+			errorHandler(err)
+			return errs
+		}
+		val := int32(parsed)
+		*t = &val
+	case *[]int32:
+		vals := make([]int32, 0, len(strs))
+		for _, str := range strs {
+			val, err := parseInt(str, 32, fieldSpec)
 			if err != nil {
 				errorHandler(err)
 				continue
 			}
-			val := int32(parsed)
-			*t = &val
-		case *[]int32:
-			for _, str := range strs {
-				val, err := strconv.ParseInt(str, 10, 32)
-				errorHandler(err)
-				*t = append(*t, int32(val))
-			}
-		case *int64:
-			val, err := strconv.ParseInt(strs[0], 10, 64)
+			vals = append(vals, int32(val))
+		}
+		if len(errs) > 0 {
+			return errs
+		}
+		*t = merge(*t, vals, fieldSpec.Merge)
+	case *int64:
+		val, err := parseInt(strs[0], 64, fieldSpec)
+		if err != nil {
+			errorHandler(err)
+			return errs
+		}
+		*t = val
+	case **int64:
+		parsed, err := parseInt(strs[0], 64, fieldSpec)
+		if err != nil {
 			errorHandler(err)
-			*t = val
-		case **int64:
-			parsed, err := strconv.ParseInt(strs[0], 10, 64)
+			return errs
+		}
+		val := int64(parsed)
+		*t = &val
+	case *[]int64:
+		vals := make([]int64, 0, len(strs))
+		for _, str := range strs {
+			val, err := parseInt(str, 64, fieldSpec)
 			if err != nil {
 				errorHandler(err)
 				continue
 			}
-			val := int64(parsed)
-			*t = &val
-		case *[]int64:
-			for _, str := range strs {
-				val, err := strconv.ParseInt(str, 10, 64)
-				errorHandler(err)
-				*t = append(*t, int64(val))
-			}
-		case *float32:
-			val, err := strconv.ParseFloat(strs[0], 32)
+			vals = append(vals, int64(val))
+		}
+		if len(errs) > 0 {
+			return errs
+		}
+		*t = merge(*t, vals, fieldSpec.Merge)
+	case *float32:
+		val, err := parseFloat(strs[0], 32, fieldSpec)
+		if err != nil {
+			errorHandler(err)
+			return errs
+		}
+		*t = float32(val)
+	case **float32:
+		parsed, err := parseFloat(strs[0], 32, fieldSpec)
+		if err != nil {
 			errorHandler(err)
-			*t = float32(val)
-		case **float32:
-			parsed, err := strconv.ParseFloat(strs[0], 32)
+			return errs
+		}
+		val := float32(parsed)
+		*t = &val
+	case *[]float32:
+		vals := make([]float32, 0, len(strs))
+		for _, str := range strs {
+			val, err := parseFloat(str, 32, fieldSpec)
 			if err != nil {
 				errorHandler(err)
 				continue
 			}
-			val := float32(parsed)
-			*t = &val
-		case *[]float32:
-			for _, str := range strs {
-				val, err := strconv.ParseFloat(str, 32)
-				errorHandler(err)
-				*t = append(*t, float32(val))
-			}
-		case *float64:
-			val, err := strconv.ParseFloat(strs[0], 64)
+			vals = append(vals, float32(val))
+		}
+		if len(errs) > 0 {
+			return errs
+		}
+		*t = merge(*t, vals, fieldSpec.Merge)
+	case *float64:
+		val, err := parseFloat(strs[0], 64, fieldSpec)
+		if err != nil {
 			errorHandler(err)
-			*t = val
-		case **float64:
-			parsed, err := strconv.ParseFloat(strs[0], 64)
+			return errs
+		}
+		*t = val
+	case **float64:
+		parsed, err := parseFloat(strs[0], 64, fieldSpec)
+		if err != nil {
+			errorHandler(err)
+			return errs
+		}
+		val := float64(parsed)
+		*t = &val
+	case *[]float64:
+		vals := make([]float64, 0, len(strs))
+		for _, str := range strs {
+			val, err := parseFloat(str, 64, fieldSpec)
 			if err != nil {
 				errorHandler(err)
 				continue
 			}
-			val := float64(parsed)
-			*t = &val
-		case *[]float64:
-			for _, str := range strs {
-				val, err := strconv.ParseFloat(str, 64)
-				errorHandler(err)
-				*t = append(*t, val)
-			}
-		case *uint:
-			val, err := strconv.ParseUint(strs[0], 10, 0)
+			vals = append(vals, val)
+		}
+		if len(errs) > 0 {
+			return errs
+		}
+		*t = merge(*t, vals, fieldSpec.Merge)
+	case *uint:
+		val, err := parseUint(strs[0], 0, fieldSpec)
+		if err != nil {
+			errorHandler(err)
+			return errs
+		}
+		*t = uint(val)
+	case **uint:
+		parsed, err := parseUint(strs[0], 0, fieldSpec)
+		if err != nil {
 			errorHandler(err)
-			*t = uint(val)
-		case **uint:
-			parsed, err := strconv.ParseUint(strs[0], 10, 0)
+			return errs
+		}
+		val := uint(parsed)
+		*t = &val
+	case *[]uint:
+		vals := make([]uint, 0, len(strs))
+		for _, str := range strs {
+			val, err := parseUint(str, 0, fieldSpec)
 			if err != nil {
 				errorHandler(err)
 				continue
 			}
-			val := uint(parsed)
-			*t = &val
-		case *[]uint:
-			for _, str := range strs {
-				val, err := strconv.ParseUint(str, 10, 0)
-				errorHandler(err)
-				*t = append(*t, uint(val))
-			}
-		case *int:
-			val, err := strconv.ParseInt(strs[0], 10, 0)
+			vals = append(vals, uint(val))
+		}
+		if len(errs) > 0 {
+			return errs
+		}
+		*t = merge(*t, vals, fieldSpec.Merge)
+	case *int:
+		val, err := parseInt(strs[0], 0, fieldSpec)
+		if err != nil {
+			errorHandler(err)
+			return errs
+		}
+		*t = int(val)
+	case **int:
+		parsed, err := parseInt(strs[0], 0, fieldSpec)
+		if err != nil {
 			errorHandler(err)
-			*t = int(val)
-		case **int:
-			parsed, err := strconv.ParseInt(strs[0], 10, 0)
+			return errs
+		}
+		val := int(parsed)
+		*t = &val
+	case *[]int:
+		vals := make([]int, 0, len(strs))
+		for _, str := range strs {
+			val, err := parseInt(str, 0, fieldSpec)
 			if err != nil {
 				errorHandler(err)
 				continue
 			}
-			val := int(parsed)
-			*t = &val
-		case *[]int:
-			for _, str := range strs {
-				val, err := strconv.ParseInt(str, 10, 0)
-				errorHandler(err)
-				*t = append(*t, int(val))
-			}
-		case *bool:
-			val, err := strconv.ParseBool(strs[0])
+			vals = append(vals, int(val))
+		}
+		if len(errs) > 0 {
+			return errs
+		}
+		*t = merge(*t, vals, fieldSpec.Merge)
+	case *bool:
+		val, err := strconv.ParseBool(strs[0])
+		if err != nil {
 			errorHandler(err)
-			*t = val
-		case **bool:
-			val, err := strconv.ParseBool(strs[0])
+			return errs
+		}
+		*t = val
+	case **bool:
+		val, err := strconv.ParseBool(strs[0])
+		if err != nil {
+			errorHandler(err)
+			return errs
+		}
+		*t = &val
+	case *[]bool:
+		vals := make([]bool, 0, len(strs))
+		for _, str := range strs {
+			val, err := strconv.ParseBool(str)
 			if err != nil {
 				errorHandler(err)
 				continue
 			}
-			*t = &val
-		case *[]bool:
-			for _, str := range strs {
-				val, err := strconv.ParseBool(str)
-				errorHandler(err)
-				*t = append(*t, val)
-			}
-		case *string:
-			*t = strs[0]
-		case **string:
-			s := strs[0]
-			*t = &s
-		case *[]string:
-			*t = strs
-		case *time.Time:
-			timeFormat := TimeFormat
-			if fieldSpec.TimeFormat != "" {
-				timeFormat = fieldSpec.TimeFormat
-			}
-			val, err := time.Parse(timeFormat, strs[0])
+			vals = append(vals, val)
+		}
+		if len(errs) > 0 {
+			return errs
+		}
+		*t = merge(*t, vals, fieldSpec.Merge)
+	case *string:
+		*t = strs[0]
+	case **string:
+		s := strs[0]
+		*t = &s
+	case *[]string:
+		*t = merge(*t, strs, fieldSpec.Merge)
+	case *time.Time:
+		timeFormat := TimeFormat
+		if fieldSpec.TimeFormat != "" {
+			timeFormat = fieldSpec.TimeFormat
+		}
+		val, err := time.Parse(timeFormat, strs[0])
+		if err != nil {
 			errorHandler(err)
-			*t = val
-		case **time.Time:
-			timeFormat := TimeFormat
-			if fieldSpec.TimeFormat != "" {
-				timeFormat = fieldSpec.TimeFormat
-			}
-			val, err := time.Parse(timeFormat, strs[0])
+			return errs
+		}
+		*t = val
+	case **time.Time:
+		timeFormat := TimeFormat
+		if fieldSpec.TimeFormat != "" {
+			timeFormat = fieldSpec.TimeFormat
+		}
+		val, err := time.Parse(timeFormat, strs[0])
+		if err != nil {
+			errorHandler(err)
+			return errs
+		}
+		*t = &val
+	case *[]time.Time:
+		timeFormat := TimeFormat
+		if fieldSpec.TimeFormat != "" {
+			timeFormat = fieldSpec.TimeFormat
+		}
+		vals := make([]time.Time, 0, len(strs))
+		for _, str := range strs {
+			val, err := time.Parse(timeFormat, str)
 			if err != nil {
 				errorHandler(err)
 				continue
 			}
-			*t = &val
-		case *[]time.Time:
-			timeFormat := TimeFormat
-			if fieldSpec.TimeFormat != "" {
-				timeFormat = fieldSpec.TimeFormat
-			}
-			for _, str := range strs {
-				val, err := time.Parse(timeFormat, str)
-				errorHandler(err)
-				*t = append(*t, val)
-			}
-		case **multipart.FileHeader:
-			if files, ok := formFile[fieldSpec.Form]; ok {
-				*t = files[0]
-			}
-
-		case *[]*multipart.FileHeader:
-			if files, ok := formFile[fieldSpec.Form]; ok {
-				for _, file := range files {
-					*t = append(*t, file)
-				}
-			}
-		default:
-			errorHandler(errors.New("Field type is unsupported by the application"))
+			vals = append(vals, val)
 		}
+		if len(errs) > 0 {
+			return errs
+		}
+		*t = mergeFunc(*t, vals, fieldSpec.Merge, time.Time.Equal)
+	case **multipart.FileHeader:
+		if len(files) > 0 {
+			*t = files[0]
+		}
+	case *[]*multipart.FileHeader:
+		if len(files) > 0 {
+			*t = merge(*t, files, fieldSpec.Merge)
+		}
+	default:
+		errorHandler(errors.New("Field type is unsupported by the application"))
 	}
 
-	return validate(errs, req, userStruct)
+	return errs
 }
 
 func fieldSpecification(fieldNameOrSpec interface{}) (Field, error) {
@@ -779,20 +1065,26 @@ type (
 	}
 
 	// FieldMap is a map of pointers to struct fields -> field names from the request.
-	// The values could also be Field structs to specify metadata about the field.
+	// The values could also be Field structs to specify metadata about the field,
+	// or Rules that span several fields.
 	FieldMap map[interface{}]interface{}
 
 	// Field describes the properties of a struct field.
 	Field struct {
-		// Target is the struct field to deserialize into.
-		//Target interface{}
+		// Target is a pointer to the struct field to deserialize into.
+		// It is only used in a FieldList; in a FieldMap, the key is the
+		// pointer.
+		Target interface{}
 
 		// Form is the form field name to bind from
 		Form string
 
 		// Required indicates whether the field is required. A required
 		// field that deserializes into the zero value for that type
-		// will generate an error.
+		// will generate an error. Fields of types that implement neither
+		// Zeroer nor are known to this package must instead be present
+		// in the request. To accept explicit zero values such as 0 or
+		// false, use the RequirePresence option.
 		Required bool
 
 		// TimeFormat specifies the time format for time.Time fields.
@@ -804,8 +1096,151 @@ type (
 		// implement the Binder interface.
 		Binder func(string, []string) error
 
-		// ErrorMessage allows the error the to be customized.
+		// ErrorMessage replaces the messages of the errors produced by
+		// the field's validation rules. It is also the key of the
+		// message in a Catalog, so it can be translated, and if no
+		// Catalog has it, it is used as a template itself; see Catalog.
 		ErrorMessage string
+
+		// Label is the name of the field in messages; see Catalog. If
+		// empty, the form name is used.
+		Label string
+
+		// Redact keeps the value of the field out of its errors, for
+		// fields such as passwords; see DetailedError.
+		Redact bool
+
+		// NullToken is the form value that marks an Optional field as
+		// explicitly null. If empty, the package-level NullToken is used.
+		NullToken string
+
+		// Merge determines how values bound into a slice field are
+		// combined with its previous contents. If not set, the
+		// package-level SliceMerge policy is used.
+		Merge MergePolicy
+
+		// MultiValue determines how a field that holds a single value
+		// handles a key repeated in the request. If not set, the
+		// package-level MultiValue policy is used, or MultiValueError
+		// in strict mode.
+		MultiValue MultiValuePolicy
+
+		// NumberPrefixes allows integer fields to be written in
+		// hexadecimal, octal or binary with the 0x, 0o and 0b
+		// prefixes. Other values are in base 10, even with a
+		// leading 0. See Underscores for digit separators.
+		NumberPrefixes bool
+
+		// Underscores allows underscores between the digits of
+		// numeric fields, as in 1_000_000.
+		Underscores bool
+
+		// ThousandsSeparator, if set, is allowed between groups of
+		// three digits in the integer part of numeric fields, e.g.
+		// "," for 1,000,000.
+		ThousandsSeparator string
+
+		// DecimalSeparator, if set, replaces the period as the decimal
+		// separator of floating-point fields, e.g. "," for 3,14.
+		DecimalSeparator string
+
+		// Finite rejects NaN and infinite values in floating-point
+		// fields.
+		Finite bool
+
+		// Min and Max are the smallest and largest values allowed in
+		// a numeric or time.Time field, or in each element of a slice
+		// of them. They may be of any integer or floating-point type,
+		// or a time.Time. Violations produce a RangeError.
+		Min, Max interface{}
+
+		// MinLen and MaxLen, if positive, limit the length in
+		// characters of a string field or of each element of a []string
+		// field, and the size in bytes of file fields. Violations
+		// produce a LengthError.
+		MinLen, MaxLen int
+
+		// MinItems and MaxItems, if positive, limit the number of
+		// elements in a slice field. Violations produce a LengthError.
+		MinItems, MaxItems int
+
+		// Pattern, if set, must match the value of the field, or each
+		// element of a slice field. Non-string values are matched in
+		// their decimal or TimeFormat form. Violations produce a
+		// PatternError.
+		Pattern *regexp.Regexp
+
+		// OneOf, if not empty, lists the values allowed in the field,
+		// or in each element of a slice field, compared like Pattern.
+		// Violations produce a OneOfError.
+		OneOf []string
+
+		// IgnoreCase makes OneOf compare values case-insensitively.
+		IgnoreCase bool
+
+		// Format names a built-in format, such as FormatEmail or
+		// FormatUUID, that the value of the field, or each element of
+		// a slice field, must conform to. Each format produces its own
+		// error kind. Binding panics if the name is not one of them.
+		Format string
+
+		// URLSchemes, if not empty, lists the schemes allowed by
+		// FormatURL, e.g. "https".
+		URLSchemes []string
+
+		// EqualTo names another field that this field must be equal
+		// to, as with a password confirmation. A violation produces a
+		// MismatchError.
+		EqualTo string
+
+		// After and Before name another numeric or time.Time field
+		// that the value of this field must be greater or less than.
+		// Violations produce an OrderError.
+		After, Before string
+
+		// RequiredIf makes the field required when a condition on
+		// another field is met, and RequiredUnless makes it required
+		// when the condition is not met.
+		RequiredIf, RequiredUnless Condition
+
+		// RequiredWith makes the field required when any of the named
+		// fields is set.
+		RequiredWith []string
+
+		// Groups, if not empty, restricts the validation rules of the
+		// field to the named validation groups; see Group and BindGroup.
+		// The field is bound regardless.
+		Groups []string
+
+		// Validator, if set, is called with the request's context after
+		// the other checks of the field pass. It is meant for checks
+		// that do I/O, such as looking up a database, and runs
+		// concurrently with the Validators of other fields; see
+		// ValidatorWorkers. The returned error is handled like the
+		// error of a Binder.
+		Validator func(context.Context) error
+
+		// Transforms are applied in order to each raw string value of
+		// the field before it is converted, and to the values of string
+		// fields decoded from JSON. See TrimSpace, CollapseSpace, Lower,
+		// Upper, StripControl, StripTags and EscapeHTML.
+		Transforms []func(string) string
+
+		// Normalize, if set, is called after the field has been bound
+		// without errors and before validation, so it can adjust the
+		// typed value, e.g. round a price. The returned error is
+		// handled like the error of a Binder.
+		Normalize func() error
+
+		// Default is the value of the field when the request does not
+		// have one. It goes through the same conversion as a value from
+		// the request, for JSON bodies too; use CheckDefaults to find
+		// defaults that don't convert. The field still counts as absent,
+		// so constraints and Normalize don't apply to it, and an
+		// Optional is not Present. A field that already has a value
+		// before binding, such as one loaded for a PATCH request,
+		// keeps it.
+		Default string
 	}
 
 	// Binder is an interface which can deserialize itself from a slice of string
@@ -829,6 +1264,23 @@ type (
 		// perform an actual credit card authorization here.
 		Validate(*http.Request) error
 	}
+
+	// Zeroer can be implemented by a field type, such as a custom
+	// Binder type, to tell whether a Required field holds its zero
+	// value. Required fields of other types that this package doesn't
+	// know are checked for presence in the request instead.
+	Zeroer interface {
+		IsZero() bool
+	}
+
+	// ContextValidator is like Validator, but receives the request's
+	// context, so that checks that do I/O can honor its deadline and
+	// cancellation. If a type implements both, only ValidateContext
+	// is called. It is not called for a request that is already
+	// canceled; binding fails with a CanceledError instead.
+	ContextValidator interface {
+		ValidateContext(context.Context, *http.Request) error
+	}
 )
 
 var (
@@ -839,6 +1291,25 @@ var (
 	// If no TimeFormat is specified for a time.Time field, this
 	// format will be used by default when parsing.
 	TimeFormat = time.RFC3339
+
+	// If no NullToken is specified for an Optional field, a form
+	// value equal to this one marks the field as explicitly null.
+	NullToken = ""
+
+	// SliceMerge is the MergePolicy for slice fields that do not
+	// specify one. The default, MergeReplace, makes a slice field hold
+	// exactly the values in the request. Set it to MergeAppend for the
+	// behavior of earlier versions of this package.
+	SliceMerge = MergeReplace
+
+	// MultiValue is the MultiValuePolicy for fields that do not specify
+	// one, outside of strict mode. The default, MultiValueFirst, keeps
+	// the behavior of earlier versions of this package.
+	MultiValue = MultiValueFirst
+
+	// ValidatorWorkers is the number of field Validators of a struct
+	// that may run at the same time, unless the Workers option is used.
+	ValidatorWorkers = 4
 )
 
 const (
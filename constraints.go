@@ -0,0 +1,321 @@
+package binding
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"mime/multipart"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// checkConstraints checks the value that fieldPointer points to against
+// the declarative constraints of fieldSpec.
+func checkConstraints(fieldPointer interface{}, fieldSpec Field) Errors {
+	var errs Errors
+
+	type params = map[string]interface{}
+	addError := func(kind, key string, extra params, message string, args ...interface{}) {
+		message = fmt.Sprintf(message, args...)
+		fieldSpec.addError(&errs, []string{fieldSpec.Form}, kind, key, message, extra)
+	}
+
+	vals, isSlice := fieldValues(fieldPointer)
+
+	if isSlice {
+		if fieldSpec.MinItems > 0 && len(vals) < fieldSpec.MinItems {
+			addError(LengthError, LengthError, params{"min": fieldSpec.MinItems, "unit": "items", "length": len(vals)},
+				"Must have at least %d items", fieldSpec.MinItems)
+		}
+		if fieldSpec.MaxItems > 0 && len(vals) > fieldSpec.MaxItems {
+			addError(LengthError, LengthError, params{"max": fieldSpec.MaxItems, "unit": "items", "length": len(vals)},
+				"Must have at most %d items", fieldSpec.MaxItems)
+		}
+	}
+
+	for _, val := range vals {
+		if fieldSpec.Min != nil {
+			if c, ok := compare(val, fieldSpec.Min); ok && c < 0 {
+				min := fieldSpec.formatBound(fieldSpec.Min)
+				addError(RangeError, RangeError, params{"min": fieldSpec.boundParam(fieldSpec.Min), "value": fieldSpec.formatBound(val)},
+					"Must be at least %s", min)
+			}
+		}
+		if fieldSpec.Max != nil {
+			if c, ok := compare(val, fieldSpec.Max); ok && c > 0 {
+				max := fieldSpec.formatBound(fieldSpec.Max)
+				addError(RangeError, RangeError, params{"max": fieldSpec.boundParam(fieldSpec.Max), "value": fieldSpec.formatBound(val)},
+					"Must be at most %s", max)
+			}
+		}
+
+		if str, ok := fieldSpec.valueString(val); ok {
+			if fieldSpec.Pattern != nil && !fieldSpec.Pattern.MatchString(str) {
+				addError(PatternError, PatternError, params{"pattern": fieldSpec.Pattern.String(), "value": str},
+					"Must match the pattern %s", fieldSpec.Pattern)
+			}
+			if len(fieldSpec.OneOf) > 0 && !fieldSpec.isOneOf(str) {
+				oneOf := strings.Join(fieldSpec.OneOf, ", ")
+				addError(OneOfError, OneOfError, params{"oneOf": oneOf, "value": str},
+					"Must be one of: %s", oneOf)
+			}
+			if kind, message, ok := checkFormat(str, fieldSpec); !ok {
+				addError(kind, fieldSpec.Format, params{"format": fieldSpec.Format, "value": str}, "%s", message)
+			}
+		}
+
+		var length int64
+		unit := "characters"
+		extra := params{}
+		switch v := val.(type) {
+		case string:
+			length = int64(utf8.RuneCountInString(v))
+			extra["value"] = v
+		case *multipart.FileHeader:
+			length, unit = v.Size, "bytes"
+		default:
+			continue
+		}
+		extra["unit"], extra["length"] = unit, length
+		if fieldSpec.MinLen > 0 && length < int64(fieldSpec.MinLen) {
+			extra["min"] = fieldSpec.MinLen
+			addError(LengthError, LengthError, extra,
+				"Must be at least %d %s long", fieldSpec.MinLen, unit)
+		}
+		if fieldSpec.MaxLen > 0 && length > int64(fieldSpec.MaxLen) {
+			extra["max"] = fieldSpec.MaxLen
+			addError(LengthError, LengthError, extra,
+				"Must be at most %d %s long", fieldSpec.MaxLen, unit)
+		}
+	}
+
+	return errs
+}
+
+// fieldValues returns the values that fieldPointer points to: none for
+// a nil pointer, one for a scalar, and every element of a slice.
+func fieldValues(fieldPointer interface{}) (vals []interface{}, isSlice bool) {
+	switch t := fieldPointer.(type) {
+	case *uint8:
+		return []interface{}{*t}, false
+	case **uint8:
+		return deref(t), false
+	case *[]uint8:
+		return elems(*t), true
+	case *uint16:
+		return []interface{}{*t}, false
+	case **uint16:
+		return deref(t), false
+	case *[]uint16:
+		return elems(*t), true
+	case *uint32:
+		return []interface{}{*t}, false
+	case **uint32:
+		return deref(t), false
+	case *[]uint32:
+		return elems(*t), true
+	case *uint64:
+		return []interface{}{*t}, false
+	case **uint64:
+		return deref(t), false
+	case *[]uint64:
+		return elems(*t), true
+	case *int8:
+		return []interface{}{*t}, false
+	case **int8:
+		return deref(t), false
+	case *[]int8:
+		return elems(*t), true
+	case *int16:
+		return []interface{}{*t}, false
+	case **int16:
+		return deref(t), false
+	case *[]int16:
+		return elems(*t), true
+	case *int32:
+		return []interface{}{*t}, false
+	case **int32:
+		return deref(t), false
+	case *[]int32:
+		return elems(*t), true
+	case *int64:
+		return []interface{}{*t}, false
+	case **int64:
+		return deref(t), false
+	case *[]int64:
+		return elems(*t), true
+	case *float32:
+		return []interface{}{*t}, false
+	case **float32:
+		return deref(t), false
+	case *[]float32:
+		return elems(*t), true
+	case *float64:
+		return []interface{}{*t}, false
+	case **float64:
+		return deref(t), false
+	case *[]float64:
+		return elems(*t), true
+	case *uint:
+		return []interface{}{*t}, false
+	case **uint:
+		return deref(t), false
+	case *[]uint:
+		return elems(*t), true
+	case *int:
+		return []interface{}{*t}, false
+	case **int:
+		return deref(t), false
+	case *[]int:
+		return elems(*t), true
+	case *bool:
+		return []interface{}{*t}, false
+	case **bool:
+		return deref(t), false
+	case *[]bool:
+		return elems(*t), true
+	case *string:
+		return []interface{}{*t}, false
+	case **string:
+		return deref(t), false
+	case *[]string:
+		return elems(*t), true
+	case *time.Time:
+		return []interface{}{*t}, false
+	case **time.Time:
+		return deref(t), false
+	case *[]time.Time:
+		return elems(*t), true
+	case **multipart.FileHeader:
+		return deref(t), false
+	case *[]*multipart.FileHeader:
+		return elems(*t), true
+	case optionalValue:
+		if p := t.valuePointer(); p != nil {
+			return fieldValues(p)
+		}
+	}
+	return nil, false
+}
+
+func deref[T any](p **T) []interface{} {
+	if *p == nil {
+		return nil
+	}
+	return []interface{}{**p}
+}
+
+func elems[T any](s []T) []interface{} {
+	vals := make([]interface{}, len(s))
+	for i, v := range s {
+		vals[i] = v
+	}
+	return vals
+}
+
+// compare compares a field value with a Min or Max bound. It reports
+// false if the two cannot be compared.
+func compare(val, bound interface{}) (int, bool) {
+	if t, ok := val.(time.Time); ok {
+		b, ok := bound.(time.Time)
+		if !ok {
+			return 0, false
+		}
+		return t.Compare(b), true
+	}
+
+	v, ok := bigFloat(val)
+	if !ok {
+		return 0, false
+	}
+	b, ok := bigFloat(bound)
+	if !ok {
+		return 0, false
+	}
+	return v.Cmp(b), true
+}
+
+// bigFloat converts any integer or floating-point value to a big.Float
+// without loss of precision.
+func bigFloat(val interface{}) (*big.Float, bool) {
+	f := new(big.Float)
+	switch v := val.(type) {
+	case int:
+		return f.SetInt64(int64(v)), true
+	case int8:
+		return f.SetInt64(int64(v)), true
+	case int16:
+		return f.SetInt64(int64(v)), true
+	case int32:
+		return f.SetInt64(int64(v)), true
+	case int64:
+		return f.SetInt64(v), true
+	case uint:
+		return f.SetUint64(uint64(v)), true
+	case uint8:
+		return f.SetUint64(uint64(v)), true
+	case uint16:
+		return f.SetUint64(uint64(v)), true
+	case uint32:
+		return f.SetUint64(uint64(v)), true
+	case uint64:
+		return f.SetUint64(v), true
+	case float32:
+		if math.IsNaN(float64(v)) {
+			return nil, false
+		}
+		return f.SetFloat64(float64(v)), true
+	case float64:
+		if math.IsNaN(v) {
+			return nil, false
+		}
+		return f.SetFloat64(v), true
+	}
+	return nil, false
+}
+
+// valueString returns the string form of a field value, against which
+// the Pattern and OneOf constraints are checked.
+func (f Field) valueString(val interface{}) (string, bool) {
+	switch v := val.(type) {
+	case string:
+		return v, true
+	case time.Time:
+		return f.formatBound(v), true
+	case *multipart.FileHeader:
+		return "", false
+	}
+	return fmt.Sprint(val), true
+}
+
+func (f Field) isOneOf(str string) bool {
+	for _, allowed := range f.OneOf {
+		if str == allowed || (f.IgnoreCase && strings.EqualFold(str, allowed)) {
+			return true
+		}
+	}
+	return false
+}
+
+// boundParam returns bound as a message parameter: a json.Number for
+// numbers, so that it is encoded as a number, or else a string.
+func (f Field) boundParam(bound interface{}) interface{} {
+	if _, ok := bound.(time.Time); ok {
+		return f.formatBound(bound)
+	}
+	return json.Number(f.formatBound(bound))
+}
+
+// formatBound formats a Min or Max bound for an error message.
+func (f Field) formatBound(bound interface{}) string {
+	if t, ok := bound.(time.Time); ok {
+		timeFormat := TimeFormat
+		if f.TimeFormat != "" {
+			timeFormat = f.TimeFormat
+		}
+		return t.Format(timeFormat)
+	}
+	return fmt.Sprint(bound)
+}
@@ -0,0 +1,149 @@
+package binding
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type ConstrainedModel struct {
+	Quantity uint64
+	Price    *float64
+	Start    time.Time
+	Name     string
+	Tags     []string
+}
+
+func (m *ConstrainedModel) FieldMap(req *http.Request) FieldMap {
+	return FieldMap{
+		&m.Quantity: Field{Form: "quantity", Min: 1, Max: uint64(1 << 63)},
+		&m.Price:    Field{Form: "price", Min: 0.5},
+		&m.Start:    Field{Form: "start", Min: time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)},
+		&m.Name:     Field{Form: "name", MinLen: 2, MaxLen: 4},
+		&m.Tags:     Field{Form: "tags", MaxItems: 2, MaxLen: 3},
+	}
+}
+
+func TestConstraints(t *testing.T) {
+	Convey("Given a struct with constrained fields", t, func() {
+		req, err := http.NewRequest("POST", "http://www.example.com", nil)
+		So(err, ShouldBeNil)
+		model := new(ConstrainedModel)
+
+		Convey("Values within the constraints should produce no errors", func() {
+			errs := bindForm(req, model, map[string][]string{
+				"quantity": {"9223372036854775808"},
+				"price":    {"0.5"},
+				"start":    {"2000-01-01T00:00:00Z"},
+				"name":     {"ünï"},
+				"tags":     {"a", "bcd"},
+			}, nil)
+			So(errs, ShouldBeEmpty)
+		})
+
+		Convey("Values outside the constraints should produce errors", func() {
+			errs := bindForm(req, model, map[string][]string{
+				"quantity": {"9223372036854775809"},
+				"price":    {"0.25"},
+				"start":    {"1999-12-31T23:59:59Z"},
+				"name":     {"x"},
+				"tags":     {"a", "b", "cdef"},
+			}, nil)
+
+			kinds := make(map[string][]string)
+			for _, e := range errs {
+				kinds[e.Fields()[0]] = append(kinds[e.Fields()[0]], e.Kind())
+			}
+			So(kinds["quantity"], ShouldResemble, []string{RangeError})
+			So(kinds["price"], ShouldResemble, []string{RangeError})
+			So(kinds["start"], ShouldResemble, []string{RangeError})
+			So(kinds["name"], ShouldResemble, []string{LengthError})
+			So(kinds["tags"], ShouldResemble, []string{LengthError, LengthError})
+		})
+
+		Convey("Fields absent from the request should not be checked", func() {
+			errs := bindForm(req, model, map[string][]string{}, nil)
+			So(errs, ShouldBeEmpty)
+		})
+
+		Convey("Fields that fail to bind should not be checked", func() {
+			errs := bindForm(req, model, map[string][]string{"quantity": {"x"}}, nil)
+			So(errs.Len(), ShouldEqual, 1)
+			So(errs.Has(TypeError), ShouldBeTrue)
+		})
+	})
+}
+
+type EnumModel struct {
+	Slug    string
+	Country string
+	Status  []string
+	Level   int
+}
+
+func (m *EnumModel) FieldMap(req *http.Request) FieldMap {
+	return FieldMap{
+		&m.Slug:    Field{Form: "slug", Pattern: regexp.MustCompile(`^[a-z0-9]+(?:-[a-z0-9]+)*$`)},
+		&m.Country: Field{Form: "country", OneOf: []string{"DE", "FR"}, IgnoreCase: true},
+		&m.Status:  Field{Form: "status", OneOf: []string{"open", "closed"}},
+		&m.Level:   Field{Form: "level", OneOf: []string{"1", "2", "3"}},
+	}
+}
+
+func TestPatternAndOneOf(t *testing.T) {
+	Convey("Given a struct with pattern and enum constraints", t, func() {
+		req, err := http.NewRequest("POST", "http://www.example.com", nil)
+		So(err, ShouldBeNil)
+		model := new(EnumModel)
+
+		Convey("Matching values should produce no errors", func() {
+			errs := bindForm(req, model, map[string][]string{
+				"slug":    {"hello-world"},
+				"country": {"de"},
+				"status":  {"open", "closed"},
+				"level":   {"2"},
+			}, nil)
+			So(errs, ShouldBeEmpty)
+		})
+
+		Convey("Other values should produce errors", func() {
+			errs := bindForm(req, model, map[string][]string{
+				"slug":    {"Hello World"},
+				"country": {"GB"},
+				"status":  {"open", "Closed"},
+				"level":   {"4"},
+			}, nil)
+			So(errs.Len(), ShouldEqual, 4)
+			for _, e := range errs {
+				switch e.Fields()[0] {
+				case "slug":
+					So(e.Kind(), ShouldEqual, PatternError)
+					So(e.Message(), ShouldContainSubstring, "^[a-z0-9]+")
+				default:
+					So(e.Kind(), ShouldEqual, OneOfError)
+				}
+			}
+		})
+	})
+}
+
+func TestConstraintsJSON(t *testing.T) {
+	Convey("Given a JSON request for a struct without json tags", t, func() {
+		req, err := http.NewRequest("POST", "http://www.example.com", strings.NewReader(`{"Name": "toolong", "Qty": 99}`))
+		So(err, ShouldBeNil)
+		req.Header.Set("Content-Type", "application/json")
+
+		Convey("The constraints should apply to the fields the keys were decoded into", func() {
+			errs, _ := Bind(req, new(UntaggedModel)).(Errors)
+			So(errs.Len(), ShouldEqual, 2)
+			So(errs[0].Fields(), ShouldResemble, []string{"name"})
+			So(errs[0].Kind(), ShouldEqual, LengthError)
+			So(errs[1].Fields(), ShouldResemble, []string{"qty"})
+			So(errs[1].Kind(), ShouldEqual, RangeError)
+		})
+	})
+}
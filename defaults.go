@@ -0,0 +1,55 @@
+package binding
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+)
+
+// CheckDefaults stores the Default of every field of userStruct in it,
+// as if binding a request without any values, and returns the errors,
+// so that a Default that doesn't convert to its field's type can be
+// caught at startup:
+//
+//	func init() {
+//		if err := binding.CheckDefaults(new(ListParams)); err != nil {
+//			panic(err)
+//		}
+//	}
+func CheckDefaults(userStruct FieldMapper) error {
+	st := &bindState{fields: make(Fields)}
+	req := new(http.Request).WithContext(context.WithValue(context.Background(), stateKey{}, st))
+
+	errs := applyDefaults(nil, fieldList(req, userStruct))
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// applyDefaults binds the Default of every field of fl. For a request
+// body such as JSON, this is done before decoding it, so that the
+// values in the body replace the defaults.
+func applyDefaults(errs Errors, fl FieldList) Errors {
+	for _, fieldSpec := range fl.fields() {
+		if fieldSpec.Default == "" {
+			continue
+		}
+		errs = append(errs, bindDefault(fieldSpec)...)
+	}
+	return errs
+}
+
+// bindDefault binds the Default of fieldSpec, unless its target already
+// has a value, such as one loaded from a database before binding. For
+// an Optional, only the Value is set, since Present and Null report
+// what the request had.
+func bindDefault(fieldSpec Field) Errors {
+	if v := reflect.ValueOf(fieldSpec.Target); v.Kind() == reflect.Ptr && !v.IsNil() && !v.Elem().IsZero() {
+		return nil
+	}
+	if o, ok := fieldSpec.Target.(optionalValue); ok {
+		return o.bindDefault(fieldSpec)
+	}
+	return bindField(fieldSpec.Target, fieldSpec, []string{fieldSpec.Default}, nil)
+}
@@ -0,0 +1,111 @@
+package binding
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type ListParams struct {
+	Page  int
+	Limit uint
+	Sort  Optional[string]
+	Kinds []string
+}
+
+func (m *ListParams) FieldMap(req *http.Request) FieldMap {
+	return FieldMap{
+		&m.Page:  Field{Form: "page", Default: "1"},
+		&m.Limit: Field{Form: "limit", Default: "20", Max: uint(100)},
+		&m.Sort:  Field{Form: "sort", Default: "name"},
+		&m.Kinds: Field{Form: "kinds", Default: "all"},
+	}
+}
+
+type BadDefaults struct {
+	Page int
+}
+
+func (m *BadDefaults) FieldMap(req *http.Request) FieldMap {
+	return FieldMap{
+		&m.Page: Field{Form: "page", Default: "first"},
+	}
+}
+
+func TestDefaults(t *testing.T) {
+	Convey("Given a struct with default values", t, func() {
+		model := new(ListParams)
+
+		Convey("Absent form fields should get their defaults", func() {
+			req, err := http.NewRequest("GET", "http://www.example.com/?page=0", nil)
+			So(err, ShouldBeNil)
+			So(Bind(req, model), ShouldBeNil)
+			So(model.Page, ShouldEqual, 0)
+			So(model.Limit, ShouldEqual, 20)
+			So(model.Sort.Value, ShouldEqual, "name")
+			So(model.Kinds, ShouldResemble, []string{"all"})
+		})
+
+		Convey("Absent JSON fields should get their defaults", func() {
+			req, err := http.NewRequest("POST", "http://www.example.com", strings.NewReader(`{"limit": 50, "kinds": []}`))
+			So(err, ShouldBeNil)
+			req.Header.Set("Content-Type", "application/json")
+			So(Bind(req, model), ShouldBeNil)
+			So(model.Page, ShouldEqual, 1)
+			So(model.Limit, ShouldEqual, 50)
+			So(model.Sort.Value, ShouldEqual, "name")
+			So(model.Kinds, ShouldBeEmpty)
+		})
+
+		Convey("JSON values should not be replaced by defaults", func() {
+			req, err := http.NewRequest("POST", "http://www.example.com", strings.NewReader(`{"Page": 7, "limit": 0}`))
+			So(err, ShouldBeNil)
+			req.Header.Set("Content-Type", "application/json")
+			So(Bind(req, model), ShouldBeNil)
+			So(model.Page, ShouldEqual, 7)
+			So(model.Limit, ShouldEqual, 0)
+			So(model.Sort.Value, ShouldEqual, "name")
+		})
+
+		Convey("An absent Optional should get the default but not be Present", func() {
+			req, err := http.NewRequest("GET", "http://www.example.com/", nil)
+			So(err, ShouldBeNil)
+			fields := make(Fields)
+			So(Bind(req, model, RecordPresence(&fields)), ShouldBeNil)
+			So(model.Sort, ShouldResemble, Optional[string]{Value: "name"})
+			So(fields.Has("sort"), ShouldBeFalse)
+		})
+
+		Convey("Values loaded before binding should not be replaced by defaults", func() {
+			model.Limit = 50
+			model.Sort = Optional[string]{Value: "date", Present: true}
+			patch, err := http.NewRequest("PATCH", "http://www.example.com", strings.NewReader("{}"))
+			So(err, ShouldBeNil)
+			patch.Header.Set("Content-Type", "application/json")
+			get, err := http.NewRequest("GET", "http://www.example.com/", nil)
+			So(err, ShouldBeNil)
+
+			for _, req := range []*http.Request{patch, get} {
+				So(Bind(req, model), ShouldBeNil)
+				So(model.Limit, ShouldEqual, 50)
+				So(model.Sort.Value, ShouldEqual, "date")
+				So(model.Page, ShouldEqual, 1)
+			}
+		})
+
+		Convey("Valid defaults should pass CheckDefaults", func() {
+			So(CheckDefaults(new(ListParams)), ShouldBeNil)
+		})
+	})
+
+	Convey("Given a default that doesn't convert", t, func() {
+		err := CheckDefaults(new(BadDefaults))
+
+		Convey("CheckDefaults should report a TypeError", func() {
+			errs, _ := err.(Errors)
+			So(errs.Has(TypeError), ShouldBeTrue)
+		})
+	})
+}
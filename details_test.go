@@ -0,0 +1,101 @@
+package binding
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type LoginForm struct {
+	User     string
+	Password string
+	Attempts int
+	Retries  uint8
+}
+
+func (f *LoginForm) FieldList(req *http.Request) FieldList {
+	return FieldList{
+		Field{Target: &f.User, Form: "user", MinLen: 3, Required: true},
+		Field{Target: &f.Password, Form: "password", MinLen: 8, Redact: true},
+		Field{Target: &f.Attempts, Form: "attempts", Min: 0},
+		Field{Target: &f.Retries, Form: "retries"},
+	}
+}
+
+func (f *LoginForm) FieldMap(req *http.Request) FieldMap {
+	return f.FieldList(req).FieldMap()
+}
+
+func TestErrorDetails(t *testing.T) {
+	Convey("Given a request with invalid values", t, func() {
+		bind := func(query string) Errors {
+			req, err := http.NewRequest("GET", "http://www.example.com/?"+query, nil)
+			So(err, ShouldBeNil)
+			errs, _ := Bind(req, new(LoginForm)).(Errors)
+			return errs
+		}
+		detail := func(errs Errors, i int) DetailedError {
+			So(len(errs), ShouldBeGreaterThan, i)
+			d, ok := errs[i].(DetailedError)
+			So(ok, ShouldBeTrue)
+			return d
+		}
+
+		Convey("A length error should have the bounds, length and value", func() {
+			d := detail(bind("user=ab"), 0)
+			So(d.Params(), ShouldResemble, map[string]interface{}{
+				"field": "user", "label": "user", "min": 3, "unit": "characters", "length": int64(2),
+			})
+			value, ok := d.RejectedValue()
+			So(ok, ShouldBeTrue)
+			So(value, ShouldEqual, "ab")
+			So(d.Redacted(), ShouldBeFalse)
+		})
+
+		Convey("A type error should have the expected type", func() {
+			d := detail(bind("user=abc&attempts=x"), 0)
+			So(d.Kind(), ShouldEqual, TypeError)
+			So(d.Params()["type"], ShouldEqual, "integer")
+			value, _ := d.RejectedValue()
+			So(value, ShouldEqual, "x")
+		})
+
+		Convey("A range error should have the bounds, even if zero", func() {
+			d := detail(bind("user=abc&attempts=-1"), 0)
+			So(d.Params()["min"], ShouldEqual, json.Number("0"))
+
+			d = detail(bind("user=abc&retries=300"), 0)
+			So(d.Kind(), ShouldEqual, RangeError)
+			So(d.Params()["min"], ShouldEqual, json.Number("0"))
+			So(d.Params()["max"], ShouldEqual, json.Number("255"))
+		})
+
+		Convey("A redacted field should leave its value out", func() {
+			d := detail(bind("user=abc&password=hunter2"), 0)
+			So(d.Redacted(), ShouldBeTrue)
+			_, ok := d.RejectedValue()
+			So(ok, ShouldBeFalse)
+			So(d.Message(), ShouldNotContainSubstring, "hunter2")
+
+			b, err := json.Marshal(d)
+			So(err, ShouldBeNil)
+			So(string(b), ShouldNotContainSubstring, "hunter2")
+			So(string(b), ShouldContainSubstring, `"redacted":true`)
+		})
+
+		Convey("The details should be encoded as JSON", func() {
+			b, err := json.Marshal(detail(bind("user=abc&attempts=-1"), 0))
+			So(err, ShouldBeNil)
+			So(string(b), ShouldEqual, `{"fieldNames":["attempts"],"pointers":["/attempts"],"classification":"RangeError",`+
+				`"message":"Must be at least 0","params":{"field":"attempts","label":"attempts","min":0},"value":"-1"}`)
+		})
+
+		Convey("An error without details should have no params or value", func() {
+			b, err := json.Marshal(NewError([]string{"a"}, RequiredError, "Required"))
+			So(err, ShouldBeNil)
+			So(string(b), ShouldEqual, `{"fieldNames":["a"],"pointers":["/a"],"classification":"RequiredError","message":"Required"}`)
+		})
+	})
+}
@@ -2,6 +2,7 @@ package binding
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 )
@@ -30,6 +31,32 @@ type (
 		Message() string
 	}
 
+	// A DetailedError is an Error that describes itself further. All the
+	// errors of this package are DetailedErrors.
+	//
+	// Params returns the parameters of the message, such as "min" and "max"
+	// for a RangeError or "type" for a TypeError; English documents them.
+	// The rejected value is not among them.
+	//
+	// RejectedValue returns the input that caused the error, if known.
+	//
+	// Redacted reports whether the rejected value was left out, as for a
+	// Field with Redact set.
+	DetailedError interface {
+		Error
+		Params() map[string]interface{}
+		RejectedValue() (string, bool)
+		Redacted() bool
+	}
+
+	// A LocatedError is an Error that locates its fields precisely. Paths
+	// returns the Path of each of its Fields, in the same order. All the
+	// errors of this package are LocatedErrors.
+	LocatedError interface {
+		Error
+		Paths() []Path
+	}
+
 	fieldsError struct {
 		// A fieldError supports zero or more field names, because an error can
 		// morph three ways:
@@ -49,6 +76,21 @@ type (
 		// an error in the 41st object. The message should help the
 		// end user find and fix the error with their request.
 		message string
+
+		// key identifies the template for the message in a Catalog,
+		// and params are the values the template can refer to.
+		key    string
+		params map[string]interface{}
+
+		// redacted reports whether the rejected value was left out.
+		redacted bool
+
+		// paths locates the fields. If nil, each field name is a key
+		// at the top level of the request.
+		paths []Path
+
+		// cause is the error that caused this one, if any.
+		cause error
 	}
 )
 
@@ -69,6 +111,43 @@ func (e *Errors) Add(fieldNames []string, kind, message string) {
 	*e = append(*e, NewError(fieldNames, kind, message))
 }
 
+// addTemplate adds an Error like Add, along with the key of the template
+// for its message in a Catalog and the parameters of the template.
+func (e *Errors) addTemplate(fieldNames []string, kind, key, message string, params map[string]interface{}) {
+	*e = append(*e, fieldsError{
+		fields:  fieldNames,
+		kind:    kind,
+		message: message,
+		key:     key,
+		params:  params,
+	})
+}
+
+// addCause adds an Error like Add, with the message of cause, which the
+// Error wraps.
+func (e *Errors) addCause(fieldNames []string, kind string, cause error) {
+	*e = append(*e, fieldsError{
+		fields:  fieldNames,
+		kind:    kind,
+		message: cause.Error(),
+		cause:   cause,
+	})
+}
+
+// addErr adds err, an error returned by user code such as a Validate
+// method: an Error or the Errors in Errors as they are, and any other
+// error like addCause.
+func (e *Errors) addErr(fieldNames []string, err error) {
+	switch t := err.(type) {
+	case Error:
+		*e = append(*e, t)
+	case Errors:
+		*e = append(*e, t...)
+	default:
+		e.addCause(fieldNames, "", err)
+	}
+}
+
 // Len returns the number of errors.
 func (e *Errors) Len() int {
 	return len(*e)
@@ -85,6 +164,29 @@ func (e *Errors) Has(kind string) bool {
 	return false
 }
 
+// hasField reports whether any Error in e is associated with the field
+// with the given name.
+func (e Errors) hasField(name string) bool {
+	for _, err := range e {
+		for _, f := range err.Fields() {
+			if f == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Unwrap returns the errors in e, so that errors.Is and errors.As
+// examine each of them, as for an error returned by errors.Join.
+func (e Errors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, err := range e {
+		errs[i] = err
+	}
+	return errs
+}
+
 // Error returns a concatenation of all its error messages.
 func (e Errors) Error() string {
 	messages := []string{}
@@ -117,6 +219,61 @@ func (e fieldsError) Message() string {
 	return e.message
 }
 
+// Unwrap returns the error that caused e, such as the *strconv.NumError
+// of a TypeError, or nil. The cause of an error about a redacted field
+// is not kept, since it may contain the value.
+func (e fieldsError) Unwrap() error {
+	return e.cause
+}
+
+// Is reports whether target is the sentinel error for the kind of e,
+// such as ErrRequired for a RequiredError.
+func (e fieldsError) Is(target error) bool {
+	sentinel, ok := sentinels[e.kind]
+	return ok && target == sentinel
+}
+
+// Paths returns the Path of each field of e.
+func (e fieldsError) Paths() []Path {
+	if e.paths != nil {
+		return e.paths
+	}
+	paths := make([]Path, len(e.fields))
+	for i, f := range e.fields {
+		paths[i] = Path{KeySegment(f)}
+	}
+	return paths
+}
+
+// Params returns the parameters of the message of e, without the
+// rejected value.
+func (e fieldsError) Params() map[string]interface{} {
+	if len(e.params) == 0 {
+		return nil
+	}
+	params := make(map[string]interface{}, len(e.params))
+	for k, v := range e.params {
+		if k != "value" {
+			params[k] = v
+		}
+	}
+	return params
+}
+
+// RejectedValue returns the input that caused e, if known.
+func (e fieldsError) RejectedValue() (string, bool) {
+	value, ok := e.params["value"]
+	if !ok {
+		return "", false
+	}
+	return fmt.Sprint(value), true
+}
+
+// Redacted reports whether the rejected value was left out of e.
+func (e fieldsError) Redacted() bool {
+	return e.redacted
+}
+
 func (e fieldsError) Error() string {
 	if len(e.fields) == 0 {
 		return e.message
@@ -131,14 +288,30 @@ func (e fieldsError) Error() string {
 }
 
 func (e fieldsError) MarshalJSON() ([]byte, error) {
+	var value *string
+	if v, ok := e.RejectedValue(); ok {
+		value = &v
+	}
+	var pointers []string
+	for _, p := range e.Paths() {
+		pointers = append(pointers, p.Pointer())
+	}
 	return json.Marshal(struct {
-		FieldNames     []string `json:"fieldNames,omitempty"`
-		Classification string   `json:"classification,omitempty"`
-		Message        string   `json:"message,omitempty"`
+		FieldNames     []string               `json:"fieldNames,omitempty"`
+		Pointers       []string               `json:"pointers,omitempty"`
+		Classification string                 `json:"classification,omitempty"`
+		Message        string                 `json:"message,omitempty"`
+		Params         map[string]interface{} `json:"params,omitempty"`
+		Value          *string                `json:"value,omitempty"`
+		Redacted       bool                   `json:"redacted,omitempty"`
 	}{
 		FieldNames:     e.fields,
+		Pointers:       pointers,
 		Classification: e.kind,
 		Message:        e.message,
+		Params:         e.Params(),
+		Value:          value,
+		Redacted:       e.redacted,
 	})
 }
 
@@ -147,4 +320,66 @@ const (
 	ContentTypeError     = "ContentTypeError"
 	DeserializationError = "DeserializationError"
 	TypeError            = "TypeError"
+	RangeError           = "RangeError"
+	LengthError          = "LengthError"
+	PatternError         = "PatternError"
+	OneOfError           = "OneOfError"
+	EmailError           = "EmailError"
+	URLError             = "URLError"
+	HostnameError        = "HostnameError"
+	IPError              = "IPError"
+	UUIDError            = "UUIDError"
+	CountryError         = "CountryError"
+	CurrencyError        = "CurrencyError"
+	PhoneError           = "PhoneError"
+	LuhnError            = "LuhnError"
+	SemverError          = "SemverError"
+	MismatchError        = "MismatchError"
+	OrderError           = "OrderError"
+	ExclusiveError       = "ExclusiveError"
+	ExactlyOneError      = "ExactlyOneError"
+	CanceledError        = "CanceledError"
+	UnknownFieldError    = "UnknownFieldError"
+	DuplicateValueError  = "DuplicateValueError"
+	TooLargeError        = "TooLargeError"
+)
+
+// The sentinel errors for the kinds of Error, for use with errors.Is. An
+// Error of kind RequiredError is ErrRequired, and so on; so is Errors
+// that holds one.
+var (
+	ErrRequired        = newSentinel(RequiredError)
+	ErrContentType     = newSentinel(ContentTypeError)
+	ErrDeserialization = newSentinel(DeserializationError)
+	ErrType            = newSentinel(TypeError)
+	ErrRange           = newSentinel(RangeError)
+	ErrLength          = newSentinel(LengthError)
+	ErrPattern         = newSentinel(PatternError)
+	ErrOneOf           = newSentinel(OneOfError)
+	ErrEmail           = newSentinel(EmailError)
+	ErrURL             = newSentinel(URLError)
+	ErrHostname        = newSentinel(HostnameError)
+	ErrIP              = newSentinel(IPError)
+	ErrUUID            = newSentinel(UUIDError)
+	ErrCountry         = newSentinel(CountryError)
+	ErrCurrency        = newSentinel(CurrencyError)
+	ErrPhone           = newSentinel(PhoneError)
+	ErrLuhn            = newSentinel(LuhnError)
+	ErrSemver          = newSentinel(SemverError)
+	ErrMismatch        = newSentinel(MismatchError)
+	ErrOrder           = newSentinel(OrderError)
+	ErrExclusive       = newSentinel(ExclusiveError)
+	ErrExactlyOne      = newSentinel(ExactlyOneError)
+	ErrCanceled        = newSentinel(CanceledError)
+	ErrUnknownField    = newSentinel(UnknownFieldError)
+	ErrDuplicateValue  = newSentinel(DuplicateValueError)
+	ErrTooLarge        = newSentinel(TooLargeError)
 )
+
+var sentinels = map[string]error{}
+
+func newSentinel(kind string) error {
+	err := errors.New("binding: " + kind)
+	sentinels[kind] = err
+	return err
+}
@@ -0,0 +1,192 @@
+package binding
+
+import (
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// Formats that can be given as Field.Format. Each one reports its own
+// error kind when a value does not conform to it.
+const (
+	FormatEmail    = "email"    // RFC 5322 addr-spec; EmailError
+	FormatURL      = "url"      // absolute URL, see Field.URLSchemes; URLError
+	FormatHostname = "hostname" // RFC 1123 host name; HostnameError
+	FormatFQDN     = "fqdn"     // fully qualified domain name; HostnameError
+	FormatIP       = "ip"       // IPv4 or IPv6 address; IPError
+	FormatIPv4     = "ipv4"     // IPv4 address; IPError
+	FormatIPv6     = "ipv6"     // IPv6 address; IPError
+	FormatUUID     = "uuid"     // RFC 4122 UUID in canonical form; UUIDError
+	FormatCountry  = "country"  // ISO 3166-1 alpha-2 country code; CountryError
+	FormatCurrency = "currency" // ISO 4217 currency code; CurrencyError
+	FormatPhone    = "phone"    // E.164 telephone number; PhoneError
+	FormatLuhn     = "luhn"     // digits with a valid Luhn checksum; LuhnError
+	FormatSemver   = "semver"   // Semantic Versioning 2.0.0; SemverError
+)
+
+type format struct {
+	kind    string
+	message string
+	valid   func(s string, f Field) bool
+}
+
+var formats = map[string]format{
+	FormatEmail:    {EmailError, "Must be a valid email address", isEmail},
+	FormatURL:      {URLError, "Must be a valid URL", isURL},
+	FormatHostname: {HostnameError, "Must be a valid host name", func(s string, f Field) bool { return isHostname(s) }},
+	FormatFQDN:     {HostnameError, "Must be a fully qualified domain name", func(s string, f Field) bool { return isFQDN(s) }},
+	FormatIP:       {IPError, "Must be a valid IP address", func(s string, f Field) bool { return net.ParseIP(s) != nil }},
+	FormatIPv4:     {IPError, "Must be a valid IPv4 address", isIPv4},
+	FormatIPv6:     {IPError, "Must be a valid IPv6 address", isIPv6},
+	FormatUUID:     {UUIDError, "Must be a valid UUID", func(s string, f Field) bool { return uuidPattern.MatchString(s) }},
+	FormatCountry:  {CountryError, "Must be an ISO 3166-1 alpha-2 country code", func(s string, f Field) bool { return isCode(countryCodes, s) }},
+	FormatCurrency: {CurrencyError, "Must be an ISO 4217 currency code", func(s string, f Field) bool { return isCode(currencyCodes, s) }},
+	FormatPhone:    {PhoneError, "Must be a phone number in E.164 format", func(s string, f Field) bool { return phonePattern.MatchString(s) }},
+	FormatLuhn:     {LuhnError, "Must be a number with a valid checksum", func(s string, f Field) bool { return isLuhn(s) }},
+	FormatSemver:   {SemverError, "Must be a semantic version", func(s string, f Field) bool { return semverPattern.MatchString(s) }},
+}
+
+var (
+	uuidPattern   = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+	phonePattern  = regexp.MustCompile(`^\+[1-9][0-9]{1,14}$`)
+	labelPattern  = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?$`)
+	semverPattern = regexp.MustCompile(`^(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)` +
+		`(?:-((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?` +
+		`(?:\+([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?$`)
+)
+
+// mustKnowFormats panics if a field of l has a Format that is not one
+// of the built-in formats, since values would pass it unchecked.
+func mustKnowFormats(l FieldList) {
+	for _, fieldSpec := range l.fields() {
+		if _, known := formats[fieldSpec.Format]; fieldSpec.Format != "" && !known {
+			panic(fmt.Sprintf("binding: field %q has an unknown Format %q", fieldSpec.Form, fieldSpec.Format))
+		}
+	}
+}
+
+// checkFormat checks str against fieldSpec.Format. It reports the kind
+// and message of the error if str does not conform.
+func checkFormat(str string, fieldSpec Field) (kind, message string, ok bool) {
+	f, known := formats[fieldSpec.Format]
+	if !known || f.valid(str, fieldSpec) {
+		return "", "", true
+	}
+	return f.kind, f.message, false
+}
+
+func isEmail(s string, f Field) bool {
+	addr, err := mail.ParseAddress(s)
+	return err == nil && addr.Name == "" && addr.Address == s
+}
+
+func isURL(s string, f Field) bool {
+	u, err := url.Parse(s)
+	if err != nil || u.Scheme == "" || (u.Host == "" && u.Opaque == "") {
+		return false
+	}
+	if len(f.URLSchemes) == 0 {
+		return true
+	}
+	for _, scheme := range f.URLSchemes {
+		if strings.EqualFold(u.Scheme, scheme) {
+			return true
+		}
+	}
+	return false
+}
+
+func isHostname(s string) bool {
+	s = strings.TrimSuffix(s, ".")
+	if s == "" || len(s) > 253 {
+		return false
+	}
+	for _, label := range strings.Split(s, ".") {
+		if !labelPattern.MatchString(label) {
+			return false
+		}
+	}
+	return true
+}
+
+func isFQDN(s string) bool {
+	s = strings.TrimSuffix(s, ".")
+	if !isHostname(s) || !strings.Contains(s, ".") {
+		return false
+	}
+	// The top-level domain may not be all-numeric.
+	tld := s[strings.LastIndex(s, ".")+1:]
+	return strings.Trim(tld, "0123456789") != ""
+}
+
+func isIPv4(s string, f Field) bool {
+	ip := net.ParseIP(s)
+	return ip != nil && ip.To4() != nil && !strings.Contains(s, ":")
+}
+
+func isIPv6(s string, f Field) bool {
+	return net.ParseIP(s) != nil && strings.Contains(s, ":")
+}
+
+func isLuhn(s string) bool {
+	if len(s) < 2 {
+		return false
+	}
+	sum := 0
+	double := false
+	for i := len(s) - 1; i >= 0; i-- {
+		c := s[i]
+		if c < '0' || c > '9' {
+			return false
+		}
+		d := int(c - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return sum%10 == 0
+}
+
+func isCode(codes string, s string) bool {
+	if len(s) < 2 || strings.ContainsAny(s, " ") {
+		return false
+	}
+	return strings.Contains(" "+codes+" ", " "+s+" ")
+}
+
+// countryCodes lists the officially assigned ISO 3166-1 alpha-2 codes.
+const countryCodes = "AD AE AF AG AI AL AM AO AQ AR AS AT AU AW AX AZ " +
+	"BA BB BD BE BF BG BH BI BJ BL BM BN BO BQ BR BS BT BV BW BY BZ " +
+	"CA CC CD CF CG CH CI CK CL CM CN CO CR CU CV CW CX CY CZ " +
+	"DE DJ DK DM DO DZ EC EE EG EH ER ES ET FI FJ FK FM FO FR " +
+	"GA GB GD GE GF GG GH GI GL GM GN GP GQ GR GS GT GU GW GY " +
+	"HK HM HN HR HT HU ID IE IL IM IN IO IQ IR IS IT JE JM JO JP " +
+	"KE KG KH KI KM KN KP KR KW KY KZ LA LB LC LI LK LR LS LT LU LV LY " +
+	"MA MC MD ME MF MG MH MK ML MM MN MO MP MQ MR MS MT MU MV MW MX MY MZ " +
+	"NA NC NE NF NG NI NL NO NP NR NU NZ OM " +
+	"PA PE PF PG PH PK PL PM PN PR PS PT PW PY QA RE RO RS RU RW " +
+	"SA SB SC SD SE SG SH SI SJ SK SL SM SN SO SR SS ST SV SX SY SZ " +
+	"TC TD TF TG TH TJ TK TL TM TN TO TR TT TV TW TZ " +
+	"UA UG UM US UY UZ VA VC VE VG VI VN VU WF WS YE YT ZA ZM ZW"
+
+// currencyCodes lists the active ISO 4217 currency codes.
+const currencyCodes = "AED AFN ALL AMD ANG AOA ARS AUD AWG AZN " +
+	"BAM BBD BDT BGN BHD BIF BMD BND BOB BOV BRL BSD BTN BWP BYN BZD " +
+	"CAD CDF CHE CHF CHW CLF CLP CNY COP COU CRC CUC CUP CVE CZK " +
+	"DJF DKK DOP DZD EGP ERN ETB EUR FJD FKP GBP GEL GHS GIP GMD GNF GTQ GYD " +
+	"HKD HNL HTG HUF IDR ILS INR IQD IRR ISK JMD JOD JPY " +
+	"KES KGS KHR KMF KPW KRW KWD KYD KZT LAK LBP LKR LRD LSL LYD " +
+	"MAD MDL MGA MKD MMK MNT MOP MRU MUR MVR MWK MXN MXV MYR MZN " +
+	"NAD NGN NIO NOK NPR NZD OMR PAB PEN PGK PHP PKR PLN PYG QAR " +
+	"RON RSD RUB RWF SAR SBD SCR SDG SEK SGD SHP SLE SLL SOS SRD SSP STN SVC SYP SZL " +
+	"THB TJS TMT TND TOP TRY TTD TWD TZS UAH UGX USD USN UYI UYU UYW UZS " +
+	"VED VES VND VUV WST XAF XAG XAU XBA XBB XBC XBD XCD XDR XOF XPD XPF XPT XSU XTS XUA XXX " +
+	"YER ZAR ZMW ZWL"
@@ -0,0 +1,93 @@
+package binding
+
+import (
+	"net/http"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestFormats(t *testing.T) {
+	cases := []struct {
+		field   Field
+		kind    string
+		valid   []string
+		invalid []string
+	}{
+		{Field{Format: FormatEmail}, EmailError,
+			[]string{"a@example.com", "first.last+tag@sub.example.org"},
+			[]string{"a", "a@", "Name <a@example.com>", "a@b@c"}},
+		{Field{Format: FormatURL, URLSchemes: []string{"https"}}, URLError,
+			[]string{"https://example.com/path?q=1"},
+			[]string{"http://example.com", "example.com", "/relative"}},
+		{Field{Format: FormatHostname}, HostnameError,
+			[]string{"localhost", "a-b.example.com"},
+			[]string{"-a.com", "a..b", "a_b.com"}},
+		{Field{Format: FormatFQDN}, HostnameError,
+			[]string{"example.com", "www.example.com."},
+			[]string{"localhost", "1.2.3.4"}},
+		{Field{Format: FormatIP}, IPError,
+			[]string{"192.168.0.1", "2001:db8::1"},
+			[]string{"256.0.0.1", "localhost", "1.2.3"}},
+		{Field{Format: FormatIPv4}, IPError,
+			[]string{"192.168.0.1"},
+			[]string{"::1", "256.0.0.1", "::ffff:1.2.3.4"}},
+		{Field{Format: FormatIPv6}, IPError,
+			[]string{"::1", "2001:db8::1"},
+			[]string{"127.0.0.1"}},
+		{Field{Format: FormatUUID}, UUIDError,
+			[]string{"123e4567-e89b-12d3-a456-426614174000"},
+			[]string{"123e4567e89b12d3a456426614174000", "xyz"}},
+		{Field{Format: FormatCountry}, CountryError,
+			[]string{"DE", "US"},
+			[]string{"de", "XX", "DEU", "DE US"}},
+		{Field{Format: FormatCurrency}, CurrencyError,
+			[]string{"EUR", "USD"},
+			[]string{"eur", "EURO", "ABC"}},
+		{Field{Format: FormatPhone}, PhoneError,
+			[]string{"+14155552671"},
+			[]string{"4155552671", "+0123", "+1234567890123456"}},
+		{Field{Format: FormatLuhn}, LuhnError,
+			[]string{"4111111111111111", "79927398713"},
+			[]string{"4111111111111112", "4111-1111"}},
+		{Field{Format: FormatSemver}, SemverError,
+			[]string{"1.2.3", "1.0.0-alpha.1+build.5"},
+			[]string{"1.2", "01.2.3", "v1.2.3"}},
+	}
+
+	Convey("Given fields with a built-in format", t, func() {
+		for _, c := range cases {
+			Convey("Format "+c.field.Format, func() {
+				for _, v := range c.valid {
+					_, _, ok := checkFormat(v, c.field)
+					So(ok, ShouldBeTrue)
+				}
+				for _, v := range c.invalid {
+					kind, _, ok := checkFormat(v, c.field)
+					So(ok, ShouldBeFalse)
+					So(kind, ShouldEqual, c.kind)
+				}
+			})
+		}
+	})
+
+	Convey("Given a field with an unknown format", t, func() {
+		req, err := http.NewRequest("GET", "http://www.example.com/?email=x", nil)
+		So(err, ShouldBeNil)
+
+		Convey("Binding should panic", func() {
+			So(func() { Bind(req, new(MisspelledFormat)) }, ShouldPanicWith,
+				`binding: field "email" has an unknown Format "e-mail"`)
+		})
+	})
+}
+
+type MisspelledFormat struct {
+	Email string
+}
+
+func (f *MisspelledFormat) FieldMap(req *http.Request) FieldMap {
+	return FieldMap{
+		&f.Email: Field{Form: "email", Format: "e-mail"},
+	}
+}
@@ -0,0 +1,61 @@
+package binding
+
+import "net/http"
+
+// Group activates the named validation groups. Rules that belong to
+// one or more groups, through Field.Groups or Rule.InGroups, are only
+// checked when one of their groups is active; rules that belong to no
+// group are always checked.
+func Group(names ...string) Option {
+	return func(o *options) {
+		o.groups = append(o.groups, names...)
+	}
+}
+
+// BindGroup is like Bind, but checks only the rules that belong to no
+// group or to the given group, e.g. "create" or "update".
+func BindGroup(req *http.Request, userStruct FieldMapper, group string, opts ...Option) error {
+	return Bind(req, userStruct, append(opts[:len(opts):len(opts)], Group(group))...)
+}
+
+// ActiveGroups returns the validation groups that are active for req.
+// It is intended for Validator implementations, which receive the
+// request being bound.
+func ActiveGroups(req *http.Request) []string {
+	if st, ok := req.Context().Value(stateKey{}).(*bindState); ok {
+		return st.groups
+	}
+	return nil
+}
+
+// InGroup reports whether the named validation group is active for req.
+func InGroup(req *http.Request, group string) bool {
+	for _, g := range ActiveGroups(req) {
+		if g == group {
+			return true
+		}
+	}
+	return false
+}
+
+// InGroups returns a copy of r that is only checked when one of the
+// given validation groups is active.
+func (r Rule) InGroups(groups ...string) Rule {
+	r.groups = append(append([]string(nil), r.groups...), groups...)
+	return r
+}
+
+// applies reports whether a rule that belongs to groups is checked.
+func (st *bindState) applies(groups []string) bool {
+	if len(groups) == 0 {
+		return true
+	}
+	for _, g := range groups {
+		for _, active := range st.groups {
+			if g == active {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,75 @@
+package binding
+
+import (
+	"net/http"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type ArticleModel struct {
+	ID     int
+	Title  string
+	Email  string
+	Phone  string
+	groups []string
+}
+
+func (m *ArticleModel) FieldMap(req *http.Request) FieldMap {
+	return FieldMap{
+		&m.ID:     Field{Form: "id", Required: true, Groups: []string{"update"}},
+		&m.Title:  Field{Form: "title", Required: true},
+		&m.Email:  "email",
+		&m.Phone:  "phone",
+		"contact": ExactlyOneOf("email", "phone").InGroups("create"),
+	}
+}
+
+func (m *ArticleModel) Validate(req *http.Request) error {
+	m.groups = ActiveGroups(req)
+	return nil
+}
+
+func TestValidationGroups(t *testing.T) {
+	Convey("Given a struct with grouped rules", t, func() {
+		req, err := http.NewRequest("POST", "http://www.example.com", nil)
+		So(err, ShouldBeNil)
+		model := new(ArticleModel)
+
+		Convey("Only ungrouped rules should be checked when no group is active", func() {
+			errs := validate(Errors{}, req, model)
+			So(errs.Len(), ShouldEqual, 1)
+			So(errs[0].Fields(), ShouldResemble, []string{"title"})
+		})
+
+		Convey("Rules of the active group should be checked", func() {
+			model.Title = "Hello"
+
+			err := Validate(req, model, Group("create"))
+			errs, _ := err.(Errors)
+			So(errs.Has(ExactlyOneError), ShouldBeTrue)
+			So(errs.Has(RequiredError), ShouldBeFalse)
+
+			err = Validate(req, model, Group("update"))
+			errs, _ = err.(Errors)
+			So(errs.Has(RequiredError), ShouldBeTrue)
+			So(errs.Has(ExactlyOneError), ShouldBeFalse)
+		})
+
+		Convey("The Validator should see the active groups", func() {
+			req, err := http.NewRequest("GET", "http://www.example.com/?title=Hi&id=1", nil)
+			So(err, ShouldBeNil)
+			So(BindGroup(req, model, "update"), ShouldBeNil)
+			So(model.groups, ShouldResemble, []string{"update"})
+		})
+
+		Convey("BindGroup should not write into the caller's options", func() {
+			req, err := http.NewRequest("GET", "http://www.example.com/?title=Hi&id=1", nil)
+			So(err, ShouldBeNil)
+			opts := make([]Option, 1, 2)
+			opts[0] = Strict()
+			So(BindGroup(req, model, "update", opts...), ShouldBeNil)
+			So(opts[:2][1], ShouldBeNil)
+		})
+	})
+}
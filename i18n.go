@@ -0,0 +1,299 @@
+package binding
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+)
+
+// A Catalog maps message keys to message templates for one locale. The
+// keys are the kinds of Error, such as RequiredError, some more specific
+// keys (see English), and the ErrorMessage of any Field. The templates
+// use the syntax of text/template, with the parameters of the Error as
+// data, such as {{.label}}, {{.min}} or {{.value}}, and the untranslated
+// message as {{.message}}. A template that refers to a parameter the
+// Error doesn't have is not used; refer to optional parameters with
+// index, as in {{if index . "min"}}.
+type Catalog map[string]string
+
+// Catalogs holds the message catalogs by locale, such as "en" or
+// "pt-BR". It is empty, so messages are not translated unless you add
+// catalogs, such as English:
+//
+//	binding.Catalogs["en"] = binding.English
+var Catalogs = map[string]Catalog{}
+
+// DefaultLocale is the locale used for requests without an
+// Accept-Language header that matches a catalog, unless the Locale
+// option is used. If it is empty, such requests get the untranslated
+// messages.
+var DefaultLocale = ""
+
+// English is a catalog for the "en" locale, and documents the keys and
+// parameters of the messages of this package. It is not in Catalogs
+// unless you add it.
+var English = Catalog{
+	RequiredError:        "{{.label}} is required",
+	"RequiredIf":         "{{.label}} is required when {{.other}} is set",
+	"RequiredUnless":     "{{.label}} is required unless {{.other}} is set",
+	TypeError:            "{{.label}} must be a valid {{.type}}",
+	RangeError:           `{{.label}} must be {{if and (index . "min") (index . "max")}}between {{.min}} and {{.max}}{{else if index . "min"}}at least {{.min}}{{else}}at most {{.max}}{{end}}`,
+	LengthError:          `{{.label}} must have {{if index . "min"}}at least {{.min}}{{else}}at most {{.max}}{{end}} {{.unit}}`,
+	PatternError:         "{{.label}} must match the pattern {{.pattern}}",
+	OneOfError:           "{{.label}} must be one of: {{.oneOf}}",
+	EmailError:           "{{.label}} must be a valid email address",
+	URLError:             "{{.label}} must be a valid URL",
+	HostnameError:        "{{.label}} must be a valid host name",
+	FormatFQDN:           "{{.label}} must be a fully qualified domain name",
+	IPError:              "{{.label}} must be a valid IP address",
+	FormatIPv4:           "{{.label}} must be a valid IPv4 address",
+	FormatIPv6:           "{{.label}} must be a valid IPv6 address",
+	UUIDError:            "{{.label}} must be a valid UUID",
+	CountryError:         "{{.label}} must be a country code",
+	CurrencyError:        "{{.label}} must be a currency code",
+	PhoneError:           "{{.label}} must be a phone number in international format",
+	LuhnError:            "{{.label}} must be a number with a valid checksum",
+	SemverError:          "{{.label}} must be a semantic version",
+	MismatchError:        "{{.label}} must be equal to {{.other}}",
+	"After":              "{{.label}} must be after {{.other}}",
+	"Before":             "{{.label}} must be before {{.other}}",
+	ExclusiveError:       "Only one of {{.fields}} may be set",
+	ExactlyOneError:      "Exactly one of {{.fields}} must be set",
+	UnknownFieldError:    `{{.field}} is not a known field{{if index . "suggestion"}}; did you mean {{.suggestion}}?{{end}}`,
+	DuplicateValueError:  "{{.label}} must have a single value, not {{.count}}",
+	CanceledError:        "The request was canceled",
+	ContentTypeError:     "The Content-Type of the request is not supported",
+	"EmptyContentType":   "The request has no Content-Type",
+	DeserializationError: "The request could not be read",
+	"EmptyBody":          "The request body is empty",
+	"JSONSyntax":         "The request body is not valid JSON (line {{.line}}, column {{.column}})",
+	TooLargeError:        "The request body is too large",
+}
+
+// Locale sets the preferred locales for the messages of the errors,
+// such as "de-CH" and "de", instead of the request's Accept-Language
+// header. The first one that has a catalog is used.
+func Locale(tags ...string) Option {
+	return func(o *options) {
+		o.locales = append(o.locales, tags...)
+	}
+}
+
+// catalog returns the catalog for the locale of req.
+func (st *bindState) catalog(req *http.Request) Catalog {
+	tags := st.locales
+	if len(tags) == 0 {
+		tags = acceptLanguages(req.Header.Get("Accept-Language"))
+	}
+	if DefaultLocale != "" {
+		tags = append(tags[:len(tags):len(tags)], DefaultLocale)
+	}
+
+	for _, tag := range tags {
+		for {
+			for locale, c := range Catalogs {
+				if strings.EqualFold(locale, tag) {
+					return c
+				}
+			}
+			i := strings.LastIndex(tag, "-")
+			if i < 0 {
+				break
+			}
+			tag = tag[:i]
+		}
+	}
+	return nil
+}
+
+// acceptLanguages returns the language tags of an Accept-Language
+// header, most preferred first.
+func acceptLanguages(header string) []string {
+	type weighted struct {
+		tag string
+		q   float64
+	}
+	var langs []weighted
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		tag = strings.TrimSpace(tag)
+		if tag == "" || tag == "*" {
+			continue
+		}
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			var err error
+			if q, err = strconv.ParseFloat(v, 64); err != nil || q <= 0 {
+				continue
+			}
+		}
+		langs = append(langs, weighted{tag, q})
+	}
+	sort.SliceStable(langs, func(i, j int) bool {
+		return langs[i].q > langs[j].q
+	})
+
+	tags := make([]string, len(langs))
+	for i, l := range langs {
+		tags[i] = l.tag
+	}
+	return tags
+}
+
+// localize renders the messages of errs with their templates. A message
+// is looked up in c by its key, then by its kind. A custom ErrorMessage
+// that is not in c is used as the template itself; no other message
+// is, as it may contain the input of the request. Errors without a
+// key, such as those added with Add, are left as they are.
+func localize(errs Errors, c Catalog) Errors {
+	for i, err := range errs {
+		e, ok := err.(fieldsError)
+		if !ok || e.key == "" {
+			continue
+		}
+
+		text, found := c[e.key]
+		if !found {
+			if e.message == e.key {
+				text, found = e.message, true
+			} else {
+				text, found = c[e.kind]
+			}
+		}
+		if !found {
+			continue
+		}
+
+		data := make(map[string]interface{}, len(e.params)+1)
+		for k, v := range e.params {
+			data[k] = v
+		}
+		data["message"] = e.message
+		if message, ok := render(text, data); ok {
+			e.message = message
+			errs[i] = e
+		}
+	}
+	return errs
+}
+
+var templates sync.Map // text -> *template.Template, or nil if invalid
+
+// render executes text as a template with data. It fails if text
+// refers to a key that data doesn't have.
+func render(text string, data map[string]interface{}) (string, bool) {
+	if !strings.Contains(text, "{{") {
+		return text, true
+	}
+
+	cached, ok := templates.Load(text)
+	if !ok {
+		t, err := template.New("").Option("missingkey=error").Parse(text)
+		if err != nil {
+			t = nil
+		}
+		cached, _ = templates.LoadOrStore(text, t)
+	}
+	t, _ := cached.(*template.Template)
+	if t == nil {
+		return "", false
+	}
+
+	var b strings.Builder
+	if t.Execute(&b, data) != nil {
+		return "", false
+	}
+	return b.String(), true
+}
+
+// label returns the name of f in messages: its Label, or else its
+// form name.
+func (f Field) label() string {
+	if f.Label != "" {
+		return f.Label
+	}
+	return f.Form
+}
+
+// addTemplate adds an Error about f to errs; see newError.
+func (f Field) addTemplate(errs *Errors, fieldNames []string, kind, key, message string, extra map[string]interface{}) {
+	*errs = append(*errs, f.newError(fieldNames, kind, key, message, extra))
+}
+
+// newError returns an Error about f, with the parameters "field" and
+// "label" and those in extra. If f is redacted, the rejected value is
+// left out.
+func (f Field) newError(fieldNames []string, kind, key, message string, extra map[string]interface{}) fieldsError {
+	params := map[string]interface{}{
+		"field": f.Form,
+		"label": f.label(),
+	}
+	for k, v := range extra {
+		params[k] = v
+	}
+	if f.Redact {
+		delete(params, "value")
+	}
+
+	return fieldsError{
+		fields:   fieldNames,
+		kind:     kind,
+		message:  message,
+		key:      key,
+		params:   params,
+		redacted: f.Redact,
+	}
+}
+
+// withCause returns e wrapping cause, unless e is redacted.
+func (e fieldsError) withCause(cause error) fieldsError {
+	if !e.redacted {
+		e.cause = cause
+	}
+	return e
+}
+
+// addError is like addTemplate, but the message is f.ErrorMessage if
+// set, which also serves as the template key.
+func (f Field) addError(errs *Errors, fieldNames []string, kind, key, message string, extra map[string]interface{}) {
+	if f.ErrorMessage != "" {
+		message, key = f.ErrorMessage, f.ErrorMessage
+	}
+	f.addTemplate(errs, fieldNames, kind, key, message, extra)
+}
+
+// typeName describes the type of value that fieldPointer holds, for
+// TypeError messages.
+func typeName(fieldPointer interface{}) string {
+	name := strings.TrimLeft(fmt.Sprintf("%T", fieldPointer), "*[]")
+	switch {
+	case strings.HasPrefix(name, "int"), strings.HasPrefix(name, "uint"):
+		return "integer"
+	case strings.HasPrefix(name, "float"):
+		return "number"
+	case name == "bool":
+		return "boolean"
+	case name == "time.Time":
+		return "time"
+	}
+	return "value"
+}
+
+// rejectedValue returns the input that caused err, an error converting
+// a value, if err records it.
+func rejectedValue(err error) (string, bool) {
+	switch e := err.(type) {
+	case *strconv.NumError:
+		return e.Num, true
+	case *rangeError:
+		return e.value, true
+	case *time.ParseError:
+		return e.Value, true
+	}
+	return "", false
+}
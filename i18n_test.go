@@ -0,0 +1,164 @@
+package binding
+
+import (
+	"net/http"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type SignupForm struct {
+	Name     string
+	Age      int
+	Nickname string
+	Password string
+}
+
+func (f *SignupForm) FieldList(req *http.Request) FieldList {
+	return FieldList{
+		Field{Target: &f.Name, Form: "name", Label: "Name", Required: true},
+		Field{Target: &f.Age, Form: "age", Min: 18},
+		Field{Target: &f.Nickname, Form: "nickname", MaxLen: 3, ErrorMessage: "{{.label}} is too long (max. {{.max}})"},
+		Field{Target: &f.Password, Form: "password", MinLen: 8, ErrorMessage: "password.weak"},
+	}
+}
+
+func (f *SignupForm) FieldMap(req *http.Request) FieldMap {
+	return f.FieldList(req).FieldMap()
+}
+
+type MaxAgeForm struct {
+	Age int
+}
+
+func (f *MaxAgeForm) FieldMap(req *http.Request) FieldMap {
+	return FieldMap{
+		&f.Age: Field{Form: "age", Max: 16},
+	}
+}
+
+func TestMessages(t *testing.T) {
+	Convey("Given a request with invalid values", t, func() {
+		defer func() { delete(Catalogs, "de"); delete(Catalogs, "en") }()
+		Catalogs["en"] = English
+		Catalogs["de"] = Catalog{
+			RequiredError:   "{{.label}} ist erforderlich",
+			RangeError:      "{{.label}} muss mindestens {{.min}} sein",
+			"password.weak": "Das Passwort ist zu schwach",
+		}
+
+		messages := func(accept string, opts ...Option) []string {
+			req, err := http.NewRequest("GET", "http://www.example.com/?age=17&nickname=abcd&password=123", nil)
+			So(err, ShouldBeNil)
+			if accept != "" {
+				req.Header.Set("Accept-Language", accept)
+			}
+			err = Bind(req, new(SignupForm), opts...)
+			var msgs []string
+			for _, e := range err.(Errors) {
+				msgs = append(msgs, e.Message())
+			}
+			return msgs
+		}
+
+		Convey("Without a locale, only custom templates should be rendered", func() {
+			So(messages(""), ShouldResemble, []string{
+				"Required",
+				"Must be at least 18",
+				"nickname is too long (max. 3)",
+				"password.weak",
+			})
+		})
+
+		Convey("The locale should come from the Accept-Language header", func() {
+			So(messages("fr-CH, en;q=0.8, de;q=0.5"), ShouldResemble, []string{
+				"Name is required",
+				"age must be at least 18",
+				"nickname is too long (max. 3)",
+				"password.weak",
+			})
+		})
+
+		Convey("The Locale option should override the header", func() {
+			So(messages("en", Locale("de-AT")), ShouldResemble, []string{
+				"Name ist erforderlich",
+				"age muss mindestens 18 sein",
+				"nickname is too long (max. 3)",
+				"Das Passwort ist zu schwach",
+			})
+		})
+
+		Convey("Conversion errors should get readable messages", func() {
+			req, err := http.NewRequest("GET", "http://www.example.com/?age=x", nil)
+			So(err, ShouldBeNil)
+			err = Bind(req, new(SignupForm), Locale("en"))
+			errs := err.(Errors)
+			So(errs[1].Message(), ShouldEqual, "age must be a valid integer")
+		})
+	})
+
+	Convey("Given an error added by a Validate method", t, func() {
+		errs := Errors{}
+		errs.Add([]string{"n"}, RequiredError, "Custom: n needed")
+		errs = localize(errs, English)
+
+		Convey("Its message should not be translated", func() {
+			So(errs[0].Message(), ShouldEqual, "Custom: n needed")
+		})
+	})
+
+	Convey("Given a value that looks like a template", t, func() {
+		req, err := http.NewRequest("GET", "http://www.example.com/?age=%7B%7B.field%7D%7D%7B%7B.label%7D%7D", nil)
+		So(err, ShouldBeNil)
+		err = Bind(req, new(MaxAgeForm), Locale("de"))
+
+		Convey("It should not be executed", func() {
+			So(err.(Errors)[0].Message(), ShouldContainSubstring, "{{.field}}{{.label}}")
+		})
+	})
+
+	Convey("Given a catalog template that refers to a missing parameter", t, func() {
+		defer func() { delete(Catalogs, "de") }()
+		Catalogs["de"] = Catalog{
+			RangeError: "{{.label}} muss mindestens {{.min}} sein",
+		}
+		req, err := http.NewRequest("GET", "http://www.example.com/?age=17", nil)
+		So(err, ShouldBeNil)
+		err = Bind(req, new(MaxAgeForm), Locale("de"))
+
+		Convey("The untranslated message should be kept", func() {
+			So(err.(Errors)[0].Message(), ShouldEqual, "Must be at most 16")
+		})
+	})
+
+	Convey("Given a request with an unsupported Content-Type", t, func() {
+		defer func() { delete(Catalogs, "en") }()
+		Catalogs["en"] = English
+		req, err := http.NewRequest("POST", "http://www.example.com", nil)
+		So(err, ShouldBeNil)
+		req.Header.Set("Content-Type", "text/csv")
+		err = Bind(req, new(SignupForm), Locale("en"))
+
+		Convey("Its message should be translated", func() {
+			So(err.(Errors)[0].Message(), ShouldEqual, "The Content-Type of the request is not supported")
+		})
+	})
+
+	Convey("Given a request that accepts English", t, func() {
+		req, err := http.NewRequest("GET", "http://www.example.com/", nil)
+		So(err, ShouldBeNil)
+		req.Header.Set("Accept-Language", "en-US")
+		err = Bind(req, new(SignupForm))
+
+		Convey("Its messages should not be translated unless English is added", func() {
+			So(err.(Errors)[0].Message(), ShouldEqual, "Required")
+		})
+	})
+
+	Convey("Given an Accept-Language header", t, func() {
+		Convey("Its tags should be ordered by quality", func() {
+			So(acceptLanguages("en;q=0.5, de-CH, *;q=0.1, fr;q=0.8, es;q=0"), ShouldResemble,
+				[]string{"de-CH", "fr", "en"})
+		})
+	})
+}
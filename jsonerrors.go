@@ -0,0 +1,239 @@
+package binding
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// jsonError returns the Error for err, an error decoding body into
+// userStruct: a TypeError for the offending field if a value has the
+// wrong type, or a DeserializationError with the position of invalid
+// JSON.
+func jsonError(req *http.Request, err error, body []byte, userStruct FieldMapper) Error {
+	if e, ok := unknownJSONField(err); ok {
+		return e
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		return jsonTypeError(req, typeErr, body, userStruct)
+	}
+
+	var syntaxErr *json.SyntaxError
+	switch {
+	case errors.As(err, &syntaxErr):
+		return jsonSyntaxError(err, body, syntaxErr.Offset)
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		return jsonSyntaxError(err, body, int64(len(body)))
+	}
+	return fieldsError{fields: []string{}, kind: DeserializationError, message: err.Error(), cause: err}
+}
+
+// jsonTypeError returns the TypeError for e, located by the path of the
+// value at which it occurred.
+func jsonTypeError(req *http.Request, e *json.UnmarshalTypeError, body []byte, userStruct FieldMapper) Error {
+	path, value, found := jsonValueAt(body, e.Offset)
+	if !found {
+		path = nil
+		if e.Field != "" {
+			for _, key := range strings.Split(e.Field, ".") {
+				path = append(path, KeySegment(key))
+			}
+		}
+	}
+	if len(path) == 0 {
+		return fieldsError{fields: []string{}, kind: DeserializationError, message: e.Error(), cause: e}
+	}
+
+	// The spec of the field gives its name, label and redaction.
+	fieldSpec, name, ok := jsonFieldAt(req, userStruct, path)
+	if !ok {
+		fieldSpec, name = Field{Form: path.String()}, path.String()
+	}
+
+	typ := jsonTypeName(e.Type)
+	params := map[string]interface{}{"type": typ}
+	if actual := strings.Fields(e.Value); len(actual) > 0 {
+		params["actual"] = actual[0]
+	}
+	if value != nil {
+		params["value"] = fmt.Sprint(value)
+	}
+
+	fe := fieldSpec.newError([]string{name}, TypeError, TypeError, "Must be a valid "+typ, params)
+	fe.paths = []Path{path}
+	return fe.withCause(e)
+}
+
+// jsonFieldAt returns the spec of the field that the value at path in a
+// JSON body is decoded into, following nested FieldMappers, along with
+// the name of the field in errors, such as "items[1].qty".
+func jsonFieldAt(req *http.Request, userStruct FieldMapper, path Path) (Field, string, bool) {
+	var name string
+	mapper := userStruct
+	for i := 0; i < len(path); i++ {
+		if path[i].IsIndex {
+			return Field{}, "", false
+		}
+		fl := fieldList(req, mapper)
+		form, ok := jsonKeysOf(mapper, fl).form(path[i].Key)
+		if !ok {
+			return Field{}, "", false
+		}
+		var fieldSpec Field
+		for _, f := range fl.fields() {
+			if f.Form == form {
+				fieldSpec = f
+			}
+		}
+		if name != "" {
+			name += "."
+		}
+		name += form
+		if i == len(path)-1 {
+			return fieldSpec, name, true
+		}
+
+		switch t := fieldSpec.Target.(type) {
+		case Nested:
+			i++
+			mappers := t.mappers()
+			if !path[i].IsIndex || path[i].Index >= len(mappers) || mappers[path[i].Index] == nil {
+				return Field{}, "", false
+			}
+			name += "[" + strconv.Itoa(path[i].Index) + "]"
+			if i == len(path)-1 {
+				return fieldSpec, name, true
+			}
+			mapper = mappers[path[i].Index]
+		case FieldMapper:
+			mapper = t
+		default:
+			return Field{}, "", false
+		}
+	}
+	return Field{}, "", false
+}
+
+// jsonValueAt walks the JSON in body to the value that ends at offset,
+// or whose array or object opens there, and returns its path and, unless
+// it is an array or object, the value itself.
+func jsonValueAt(body []byte, offset int64) (Path, interface{}, bool) {
+	type container struct {
+		array  bool
+		index  int
+		key    string
+		hasKey bool
+	}
+	var stack []container
+
+	// done moves past a value in the innermost container.
+	done := func() {
+		if n := len(stack); n > 0 {
+			stack[n-1].index++
+			stack[n-1].hasKey = false
+		}
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, nil, false
+		}
+
+		delim, isDelim := token.(json.Delim)
+		if isDelim && (delim == '}' || delim == ']') {
+			stack = stack[:len(stack)-1]
+			done()
+			continue
+		}
+		if n := len(stack); n > 0 && !stack[n-1].array && !stack[n-1].hasKey {
+			stack[n-1].key, stack[n-1].hasKey = token.(string)
+			continue
+		}
+
+		if decoder.InputOffset() >= offset {
+			path := make(Path, len(stack))
+			for i, c := range stack {
+				if c.array {
+					path[i] = IndexSegment(c.index)
+				} else {
+					path[i] = KeySegment(c.key)
+				}
+			}
+			if isDelim {
+				return path, nil, true
+			}
+			return path, token, true
+		}
+
+		if isDelim {
+			stack = append(stack, container{array: delim == '['})
+		} else {
+			done()
+		}
+	}
+}
+
+// jsonTypeName describes t, the type of a field that JSON is decoded
+// into, like typeName.
+func jsonTypeName(t reflect.Type) string {
+	if t == nil {
+		return "value"
+	}
+	if t == reflect.TypeOf(time.Time{}) {
+		return "time"
+	}
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.String:
+		return "string"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	case reflect.Map, reflect.Struct:
+		return "object"
+	}
+	return "value"
+}
+
+// jsonSyntaxError returns the DeserializationError for err, invalid JSON
+// in body detected after reading offset bytes of it, with the line and
+// column of the offending character.
+func jsonSyntaxError(err error, body []byte, offset int64) Error {
+	if offset > int64(len(body)) {
+		offset = int64(len(body))
+	}
+	pos := offset
+	if pos > 0 {
+		pos--
+	}
+	start := bytes.LastIndexByte(body[:pos], '\n') + 1
+	line := bytes.Count(body[:pos], []byte("\n")) + 1
+	column := utf8.RuneCount(body[start:pos]) + 1
+
+	return fieldsError{
+		fields:  []string{},
+		kind:    DeserializationError,
+		message: fmt.Sprintf("Invalid JSON at line %d, column %d: %s", line, column, strings.TrimPrefix(err.Error(), "json: ")),
+		key:     "JSONSyntax",
+		params:  map[string]interface{}{"line": line, "column": column, "offset": offset},
+		cause:   err,
+	}
+}
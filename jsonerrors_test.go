@@ -0,0 +1,92 @@
+package binding
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type PinForm struct {
+	Pin int
+}
+
+func (f *PinForm) FieldMap(req *http.Request) FieldMap {
+	return FieldMap{
+		&f.Pin: Field{Form: "pin", Redact: true},
+	}
+}
+
+func TestJSONErrors(t *testing.T) {
+	Convey("Given a JSON request that cannot be decoded", t, func() {
+		bind := func(body string, userStruct FieldMapper) DetailedError {
+			req, err := http.NewRequest("POST", "http://www.example.com", strings.NewReader(body))
+			So(err, ShouldBeNil)
+			req.Header.Set("Content-Type", "application/json")
+			errs, _ := Bind(req, userStruct).(Errors)
+			So(errs.Len(), ShouldEqual, 1)
+			return errs[0].(DetailedError)
+		}
+
+		Convey("A value of the wrong type should be a TypeError for its field", func() {
+			e := bind(`{"items": [{"qty": 1}, {"qty": "two"}]}`, new(OrderModel))
+			So(e.Kind(), ShouldEqual, TypeError)
+			So(e.Fields(), ShouldResemble, []string{"items[1].qty"})
+			So(e.(LocatedError).Paths()[0].Pointer(), ShouldEqual, "/items/1/qty")
+			So(e.Message(), ShouldEqual, "Must be a valid integer")
+			So(e.Params()["type"], ShouldEqual, "integer")
+			So(e.Params()["actual"], ShouldEqual, "string")
+			value, _ := e.RejectedValue()
+			So(value, ShouldEqual, "two")
+		})
+
+		Convey("An object where a scalar is expected should be located too", func() {
+			e := bind(`{"shipping": {"address": {"zip": {"code": 1}}}}`, new(OrderModel))
+			So(e.Kind(), ShouldEqual, TypeError)
+			So(e.Fields(), ShouldResemble, []string{"shipping.address.zip"})
+			So(e.Params()["type"], ShouldEqual, "string")
+			_, ok := e.RejectedValue()
+			So(ok, ShouldBeFalse)
+		})
+
+		Convey("A top-level field should use its spec", func() {
+			e := bind(`{"user": "abc", "password": 12345678}`, new(LoginForm))
+			So(e.Fields(), ShouldResemble, []string{"password"})
+			So(e.Redacted(), ShouldBeTrue)
+			_, ok := e.RejectedValue()
+			So(ok, ShouldBeFalse)
+		})
+
+		Convey("A field should be found as encoding/json finds it", func() {
+			e := bind(`{"Pin": "secret-1234"}`, new(PinForm))
+			So(e.Fields(), ShouldResemble, []string{"pin"})
+			So(e.(LocatedError).Paths()[0].Pointer(), ShouldEqual, "/Pin")
+			So(e.Redacted(), ShouldBeTrue)
+			So(errors.Unwrap(e), ShouldBeNil)
+
+			b, err := json.Marshal(e)
+			So(err, ShouldBeNil)
+			So(string(b), ShouldNotContainSubstring, "secret")
+		})
+
+		Convey("A syntax error should have its position", func() {
+			e := bind("{\n  \"user\": \"abc\",\n  \"attempts\": x\n}", new(LoginForm))
+			So(e.Kind(), ShouldEqual, DeserializationError)
+			So(e.Fields(), ShouldBeEmpty)
+			So(e.Params()["line"], ShouldEqual, 3)
+			So(e.Params()["column"], ShouldEqual, 15)
+			So(e.Params()["offset"], ShouldEqual, int64(34))
+			So(e.Message(), ShouldStartWith, "Invalid JSON at line 3, column 15: invalid character 'x'")
+		})
+
+		Convey("A truncated body should be a syntax error at its end", func() {
+			e := bind(`{"user": "abc"`, new(LoginForm))
+			So(e.Kind(), ShouldEqual, DeserializationError)
+			So(e.Params()["line"], ShouldEqual, 1)
+			So(e.Params()["offset"], ShouldEqual, int64(14))
+		})
+	})
+}
@@ -0,0 +1,184 @@
+package binding
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// jsonKeys maps the keys of a JSON object to the Form names of the
+// fields that encoding/json decodes them into.
+type jsonKeys []jsonKey
+
+type jsonKey struct {
+	name string // the name of the struct field in JSON
+	form string
+}
+
+// jsonKeysOf returns the jsonKeys of the fields of fl. The JSON name of
+// a field is that of the struct field of userStruct its target points
+// to; a field whose target is not a struct field goes by its Form name.
+func jsonKeysOf(userStruct FieldMapper, fl FieldList) jsonKeys {
+	type target struct {
+		addr uintptr
+		typ  reflect.Type
+	}
+	forms := make(map[target]string)
+	for _, fieldSpec := range fl.fields() {
+		ptr := fieldSpec.Target
+		if n, ok := ptr.(Nested); ok {
+			ptr = n.slice()
+		}
+		if v := reflect.ValueOf(ptr); v.Kind() == reflect.Ptr && !v.IsNil() {
+			forms[target{v.Pointer(), v.Type().Elem()}] = fieldSpec.Form
+		}
+	}
+
+	var keys jsonKeys
+	matched := make(map[string]bool)
+	if v := reflect.ValueOf(userStruct); v.Kind() == reflect.Ptr && !v.IsNil() && v.Elem().Kind() == reflect.Struct {
+		v = v.Elem()
+		for _, f := range jsonFields(v.Type()) {
+			fv, err := v.FieldByIndexErr(f.index)
+			if err != nil {
+				continue
+			}
+			t := target{fv.Addr().Pointer(), fv.Type()}
+			if form, ok := forms[t]; ok {
+				keys = append(keys, jsonKey{f.name, form})
+				matched[form] = true
+			}
+		}
+	}
+	for _, fieldSpec := range fl.fields() {
+		if !matched[fieldSpec.Form] {
+			keys = append(keys, jsonKey{fieldSpec.Form, fieldSpec.Form})
+		}
+	}
+	return keys
+}
+
+// form returns the Form name of the field that key is decoded into.
+// Like encoding/json, it prefers an exact match to a case-insensitive
+// one.
+func (k jsonKeys) form(key string) (string, bool) {
+	for _, jk := range k {
+		if jk.name == key {
+			return jk.form, true
+		}
+	}
+	for _, jk := range k {
+		if strings.EqualFold(jk.name, key) {
+			return jk.form, true
+		}
+	}
+	return "", false
+}
+
+// name returns the JSON name of the field with the given Form name, or
+// form itself if the field is not a struct field.
+func (k jsonKeys) name(form string) string {
+	for _, jk := range k {
+		if jk.form == form {
+			return jk.name
+		}
+	}
+	return form
+}
+
+// withJSONPaths sets the paths of errs, whose fields go by their Form
+// names, to the keys of the JSON object that they were decoded from.
+func withJSONPaths(errs Errors, keys jsonKeys) {
+	for i, e := range errs {
+		fe, ok := e.(fieldsError)
+		if !ok {
+			continue
+		}
+		paths := errorPaths(fe)
+		fe.paths = make([]Path, len(paths))
+		for j, p := range paths {
+			if len(p) > 0 && !p[0].IsIndex {
+				p = append(Path{KeySegment(keys.name(p[0].Key))}, p[1:]...)
+			}
+			fe.paths[j] = p
+		}
+		errs[i] = fe
+	}
+}
+
+type jsonField struct {
+	name   string
+	index  []int
+	tagged bool
+}
+
+var jsonFieldCache sync.Map // reflect.Type -> []jsonField
+
+// jsonFields returns the fields of the struct type t that encoding/json
+// decodes into, with their names, including those promoted from
+// embedded structs. As with encoding/json, a name used by several
+// fields belongs to the least nested one, preferring a tagged one, and
+// to none if that is still ambiguous.
+func jsonFields(t reflect.Type) []jsonField {
+	if cached, ok := jsonFieldCache.Load(t); ok {
+		return cached.([]jsonField)
+	}
+
+	var all []jsonField
+	var collect func(t reflect.Type, index []int)
+	collect = func(t reflect.Type, index []int) {
+		for i := 0; i < t.NumField(); i++ {
+			sf := t.Field(i)
+			ft := sf.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if !sf.IsExported() && !(sf.Anonymous && ft.Kind() == reflect.Struct) {
+				continue
+			}
+			tag := sf.Tag.Get("json")
+			if tag == "-" {
+				continue
+			}
+			name, _, _ := strings.Cut(tag, ",")
+			fieldIndex := append(index[:len(index):len(index)], i)
+			if name == "" && sf.Anonymous && ft.Kind() == reflect.Struct {
+				if len(fieldIndex) < 10 {
+					collect(ft, fieldIndex)
+				}
+				continue
+			}
+			if !sf.IsExported() {
+				continue
+			}
+			if name == "" {
+				all = append(all, jsonField{sf.Name, fieldIndex, false})
+			} else {
+				all = append(all, jsonField{name, fieldIndex, true})
+			}
+		}
+	}
+	collect(t, nil)
+
+	var fields []jsonField
+	for i, f := range all {
+		dominant, ambiguous := true, false
+		for j, g := range all {
+			if i == j || g.name != f.name {
+				continue
+			}
+			switch {
+			case len(g.index) < len(f.index), len(g.index) == len(f.index) && g.tagged && !f.tagged:
+				dominant = false
+			case len(g.index) == len(f.index) && g.tagged == f.tagged:
+				ambiguous = true
+			}
+		}
+		if dominant && !ambiguous {
+			fields = append(fields, f)
+		}
+	}
+
+	jsonFieldCache.Store(t, fields)
+	return fields
+}
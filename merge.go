@@ -0,0 +1,57 @@
+package binding
+
+// MergePolicy determines how the values bound into a slice field are
+// combined with the values the slice already holds, for example after
+// pre-loading an entity for an edit form or when binding twice.
+type MergePolicy int
+
+const (
+	// MergeDefault defers to the package-level SliceMerge policy.
+	MergeDefault MergePolicy = iota
+
+	// MergeReplace discards the slice's previous contents.
+	MergeReplace
+
+	// MergeAppend appends the bound values to the slice.
+	MergeAppend
+
+	// MergeUnion appends the bound values that the slice does not
+	// hold yet, and drops duplicates among the bound values.
+	MergeUnion
+)
+
+// merge combines the slice dst with the newly bound values src
+// according to policy.
+func merge[T comparable](dst, src []T, policy MergePolicy) []T {
+	return mergeFunc(dst, src, policy, func(a, b T) bool { return a == b })
+}
+
+// mergeFunc is like merge but compares values with equal.
+func mergeFunc[T any](dst, src []T, policy MergePolicy, equal func(a, b T) bool) []T {
+	if policy == MergeDefault {
+		policy = SliceMerge
+	}
+
+	switch policy {
+	case MergeAppend:
+		return append(dst, src...)
+	case MergeUnion:
+		for _, v := range src {
+			if !containsFunc(dst, v, equal) {
+				dst = append(dst, v)
+			}
+		}
+		return dst
+	default:
+		return append([]T(nil), src...)
+	}
+}
+
+func containsFunc[T any](s []T, v T, equal func(a, b T) bool) bool {
+	for _, e := range s {
+		if equal(e, v) {
+			return true
+		}
+	}
+	return false
+}
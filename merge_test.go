@@ -0,0 +1,83 @@
+package binding
+
+import (
+	"mime/multipart"
+	"net/http"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type MergeModel struct {
+	Replaced []int
+	Appended []int
+	United   []string
+	Files    []*multipart.FileHeader
+	More     []*multipart.FileHeader
+}
+
+func (m *MergeModel) FieldMap(req *http.Request) FieldMap {
+	return FieldMap{
+		&m.Replaced: "replaced",
+		&m.Appended: Field{Form: "appended", Merge: MergeAppend},
+		&m.United:   Field{Form: "united", Merge: MergeUnion},
+		&m.Files:    "files",
+		&m.More:     Field{Form: "more", Merge: MergeAppend},
+	}
+}
+
+func TestMerge(t *testing.T) {
+	Convey("Given a struct whose slices already hold values", t, func() {
+		req, err := http.NewRequest("POST", "http://www.example.com", nil)
+		So(err, ShouldBeNil)
+		a, b, c := &multipart.FileHeader{Filename: "a"}, &multipart.FileHeader{Filename: "b"}, &multipart.FileHeader{Filename: "c"}
+		model := &MergeModel{
+			Replaced: []int{1, 2},
+			Appended: []int{1, 2},
+			United:   []string{"a", "b"},
+			Files:    []*multipart.FileHeader{a},
+			More:     []*multipart.FileHeader{a},
+		}
+		formData := map[string][]string{
+			"replaced": {"2", "3"},
+			"appended": {"2", "3"},
+			"united":   {"b", "c", "c"},
+		}
+
+		formFile := map[string][]*multipart.FileHeader{
+			"files": {b, c},
+			"more":  {b, c},
+		}
+
+		Convey("When bindForm is called", func() {
+			errs := bindForm(req, model, formData, formFile)
+			So(errs, ShouldBeEmpty)
+
+			Convey("The default policy should replace the values", func() {
+				So(model.Replaced, ShouldResemble, []int{2, 3})
+			})
+
+			Convey("MergeAppend should append the values", func() {
+				So(model.Appended, ShouldResemble, []int{1, 2, 2, 3})
+			})
+
+			Convey("MergeUnion should add only new values", func() {
+				So(model.United, ShouldResemble, []string{"a", "b", "c"})
+			})
+
+			Convey("The policies should apply to file slices too", func() {
+				So(model.Files, ShouldResemble, []*multipart.FileHeader{b, c})
+				So(model.More, ShouldResemble, []*multipart.FileHeader{a, b, c})
+			})
+		})
+
+		Convey("When a slice field is absent from the request", func() {
+			errs := bindForm(req, model, map[string][]string{}, nil)
+			So(errs, ShouldBeEmpty)
+
+			Convey("Its values should be left alone", func() {
+				So(model.Replaced, ShouldResemble, []int{1, 2})
+			})
+		})
+	})
+}
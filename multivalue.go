@@ -0,0 +1,74 @@
+package binding
+
+import (
+	"fmt"
+	"mime/multipart"
+	"time"
+)
+
+// MultiValuePolicy determines which value a field that holds a single
+// value gets when the request repeats its key, as in
+// ?role=user&role=admin. Picking one silently can make the application
+// read the request differently than a proxy or firewall in front of it.
+type MultiValuePolicy int
+
+const (
+	// MultiValueDefault defers to the package-level MultiValue policy,
+	// or to MultiValueError in strict mode.
+	MultiValueDefault MultiValuePolicy = iota
+
+	// MultiValueFirst uses the first value.
+	MultiValueFirst
+
+	// MultiValueLast uses the last value.
+	MultiValueLast
+
+	// MultiValueError rejects the field with a DuplicateValueError.
+	MultiValueError
+)
+
+// single applies the MultiValue policy of fieldSpec to the values of a
+// field that holds a single value.
+func single(fieldSpec Field, strs []string, files []*multipart.FileHeader) ([]string, []*multipart.FileHeader, Errors) {
+	var errs Errors
+
+	n := len(strs)
+	if len(files) > n {
+		n = len(files)
+	}
+	if n < 2 {
+		return strs, files, nil
+	}
+
+	policy := fieldSpec.MultiValue
+	if policy == MultiValueDefault {
+		policy = MultiValue
+	}
+
+	switch policy {
+	case MultiValueLast:
+		if len(strs) > 1 {
+			strs = strs[len(strs)-1:]
+		}
+		if len(files) > 1 {
+			files = files[len(files)-1:]
+		}
+	case MultiValueError:
+		fieldSpec.addTemplate(&errs, []string{fieldSpec.Form}, DuplicateValueError, DuplicateValueError,
+			fmt.Sprintf("Expected a single value, got %d", n), map[string]interface{}{"count": n})
+	}
+
+	return strs, files, errs
+}
+
+// isSlice reports whether fieldPointer points to a slice field.
+func isSlice(fieldPointer interface{}) bool {
+	switch fieldPointer.(type) {
+	case *[]uint8, *[]uint16, *[]uint32, *[]uint64,
+		*[]int8, *[]int16, *[]int32, *[]int64,
+		*[]float32, *[]float64, *[]uint, *[]int,
+		*[]bool, *[]string, *[]time.Time, *[]*multipart.FileHeader:
+		return true
+	}
+	return false
+}
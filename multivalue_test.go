@@ -0,0 +1,61 @@
+package binding
+
+import (
+	"net/http"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type RoleModel struct {
+	Role  string
+	Level *int
+	Tags  []string
+	Last  string
+}
+
+func (m *RoleModel) FieldMap(req *http.Request) FieldMap {
+	return FieldMap{
+		&m.Role:  "role",
+		&m.Level: "level",
+		&m.Tags:  "tags",
+		&m.Last:  Field{Form: "last", MultiValue: MultiValueLast},
+	}
+}
+
+func TestMultiValue(t *testing.T) {
+	Convey("Given a request that repeats keys", t, func() {
+		req, err := http.NewRequest("GET", "http://www.example.com/?role=user&role=admin&level=1&level=2&tags=a&tags=b&last=x&last=y", nil)
+		So(err, ShouldBeNil)
+		model := new(RoleModel)
+
+		Convey("By default, scalar fields should get the first value", func() {
+			So(Bind(req, model), ShouldBeNil)
+			So(model.Role, ShouldEqual, "user")
+			So(*model.Level, ShouldEqual, 1)
+			So(model.Tags, ShouldResemble, []string{"a", "b"})
+			So(model.Last, ShouldEqual, "y")
+		})
+
+		Convey("In strict mode, scalar fields should produce a DuplicateValueError", func() {
+			err := Bind(req, model, Strict())
+			errs, _ := err.(Errors)
+			So(errs.Len(), ShouldEqual, 2)
+			for _, e := range errs {
+				So(e.Kind(), ShouldEqual, DuplicateValueError)
+				So(e.Fields()[0], ShouldBeIn, "role", "level")
+			}
+			So(model.Role, ShouldEqual, "")
+			So(model.Tags, ShouldResemble, []string{"a", "b"})
+			So(model.Last, ShouldEqual, "y")
+		})
+
+		Convey("The package-level policy should apply to fields without one", func() {
+			defer func(p MultiValuePolicy) { MultiValue = p }(MultiValue)
+			MultiValue = MultiValueLast
+			So(Bind(req, model), ShouldBeNil)
+			So(model.Role, ShouldEqual, "admin")
+			So(*model.Level, ShouldEqual, 2)
+		})
+	})
+}
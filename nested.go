@@ -0,0 +1,161 @@
+package binding
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+// Nested is a slice of FieldMappers to be validated along with the
+// struct that holds it. Use Each or EachPtr to make one, and use it as
+// the key of the field in the FieldMap:
+//
+//	binding.Each(&f.Items): "items",
+//
+// Fields whose pointer is itself a FieldMapper, such as a struct field
+// of a type with a FieldMap method, are validated without a wrapper.
+type Nested interface {
+	mappers() []FieldMapper
+
+	// slice returns the pointer to the slice.
+	slice() interface{}
+}
+
+// Each returns the Nested value for a slice of structs whose pointers
+// are FieldMappers.
+func Each[T any, P interface {
+	*T
+	FieldMapper
+}](items *[]T) Nested {
+	return each[T, P]{items}
+}
+
+// EachPtr returns the Nested value for a slice of pointers to structs
+// that are FieldMappers. Nil elements are skipped.
+func EachPtr[T any, P interface {
+	*T
+	FieldMapper
+}](items *[]P) Nested {
+	return eachPtr[T, P]{items}
+}
+
+type each[T any, P interface {
+	*T
+	FieldMapper
+}] struct {
+	items *[]T
+}
+
+func (e each[T, P]) slice() interface{} {
+	return e.items
+}
+
+func (e each[T, P]) mappers() []FieldMapper {
+	mappers := make([]FieldMapper, len(*e.items))
+	for i := range *e.items {
+		mappers[i] = P(&(*e.items)[i])
+	}
+	return mappers
+}
+
+type eachPtr[T any, P interface {
+	*T
+	FieldMapper
+}] struct {
+	items *[]P
+}
+
+func (e eachPtr[T, P]) slice() interface{} {
+	return e.items
+}
+
+func (e eachPtr[T, P]) mappers() []FieldMapper {
+	mappers := make([]FieldMapper, len(*e.items))
+	for i, item := range *e.items {
+		if item != nil {
+			mappers[i] = item
+		}
+	}
+	return mappers
+}
+
+// validateNested validates the FieldMappers held by fieldPointer, if
+// any, and returns their errors with the field names prefixed by the
+// path of the field, as in "address.zip" or "items[3].qty".
+func validateNested(req *http.Request, st *bindState, fieldPointer interface{}, name string) Errors {
+	var errs Errors
+
+	switch t := fieldPointer.(type) {
+	case Nested:
+		for i, m := range t.mappers() {
+			if m != nil {
+				errs = append(errs, validatePath(req, st, m, Path{KeySegment(name), IndexSegment(i)})...)
+			}
+		}
+	case FieldMapper:
+		errs = validatePath(req, st, t, Path{KeySegment(name)})
+	}
+
+	return errs
+}
+
+func validatePath(req *http.Request, st *bindState, userStruct FieldMapper, path Path) Errors {
+	name := path.String()
+	sub := &bindState{options: st.options, json: st.json}
+	req = req.WithContext(context.WithValue(req.Context(), stateKey{}, sub))
+	var keys jsonKeys
+	if st.json {
+		keys = jsonKeysOf(userStruct, fieldList(req, userStruct))
+		sub.fields = st.fields.under(name).fromJSON(keys)
+	}
+
+	errs := validate(Errors{}, req, userStruct)
+	if st.json {
+		withJSONPaths(errs, keys)
+	}
+	for i, e := range errs {
+		fields, paths := []string{name}, []Path{path}
+		if len(e.Fields()) > 0 {
+			fields = make([]string, len(e.Fields()))
+			paths = make([]Path, len(e.Fields()))
+			for i, p := range errorPaths(e) {
+				fields[i] = name + "." + e.Fields()[i]
+				paths[i] = path.join(p)
+			}
+		}
+		fe, ok := e.(fieldsError)
+		if !ok {
+			fe = fieldsError{kind: e.Kind(), message: e.Message(), cause: e}
+		}
+		fe.fields, fe.paths = fields, paths
+		errs[i] = fe
+	}
+	return errs
+}
+
+// errorPaths returns the Path of each field of e.
+func errorPaths(e Error) []Path {
+	if l, ok := e.(LocatedError); ok && len(l.Paths()) == len(e.Fields()) {
+		return l.Paths()
+	}
+	paths := make([]Path, len(e.Fields()))
+	for i, f := range e.Fields() {
+		paths[i] = Path{KeySegment(f)}
+	}
+	return paths
+}
+
+// under returns the fields nested under path, with the path removed
+// from their names.
+func (f Fields) under(path string) Fields {
+	if f == nil {
+		return nil
+	}
+	sub := make(Fields)
+	for name := range f {
+		if strings.HasPrefix(name, path+".") {
+			sub[name[len(path)+1:]] = struct{}{}
+		}
+	}
+	return sub
+}
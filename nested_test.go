@@ -0,0 +1,131 @@
+package binding
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type OrderModel struct {
+	Shipping ShippingModel `json:"shipping"`
+	Items    []LineItem    `json:"items"`
+	Notes    []*LineItem   `json:"notes"`
+}
+
+func (m *OrderModel) FieldMap(req *http.Request) FieldMap {
+	return FieldMap{
+		&m.Shipping:       "shipping",
+		Each(&m.Items):    Field{Form: "items", Required: true},
+		EachPtr(&m.Notes): "notes",
+	}
+}
+
+type ShippingModel struct {
+	Address AddressModel `json:"address"`
+}
+
+func (m *ShippingModel) FieldMap(req *http.Request) FieldMap {
+	return FieldMap{
+		&m.Address: "address",
+	}
+}
+
+type AddressModel struct {
+	Zip     string `json:"zip"`
+	Country string `json:"country"`
+}
+
+func (m *AddressModel) FieldMap(req *http.Request) FieldMap {
+	return FieldMap{
+		&m.Zip:     Field{Form: "zip", Required: true},
+		&m.Country: "country",
+	}
+}
+
+func (m *AddressModel) Validate(req *http.Request) error {
+	if m.Country == "XX" {
+		return errors.New("Unknown country")
+	}
+	return nil
+}
+
+type LineItem struct {
+	Qty int `json:"qty"`
+}
+
+func (m *LineItem) FieldMap(req *http.Request) FieldMap {
+	return FieldMap{
+		&m.Qty: Field{Form: "qty", Min: 1},
+	}
+}
+
+func TestNestedValidation(t *testing.T) {
+	Convey("Given a struct with nested FieldMappers", t, func() {
+		bind := func(data string) Errors {
+			req, err := http.NewRequest("POST", "http://www.example.com", strings.NewReader(data))
+			So(err, ShouldBeNil)
+			req.Header.Set("Content-Type", "application/json")
+			err = Bind(req, new(OrderModel))
+			errs, _ := err.(Errors)
+			return errs
+		}
+		fields := func(errs Errors) []string {
+			var names []string
+			for _, e := range errs {
+				names = append(names, strings.Join(e.Fields(), ","))
+			}
+			return names
+		}
+
+		Convey("Valid nested values should produce no errors", func() {
+			errs := bind(`{"shipping": {"address": {"zip": "12345"}}, "items": [{"qty": 1}]}`)
+			So(errs, ShouldBeEmpty)
+		})
+
+		Convey("Errors of nested fields should be prefixed with their path", func() {
+			errs := bind(`{
+				"shipping": {"address": {"country": "XX"}},
+				"items": [{"qty": 1}, {"qty": 0}],
+				"notes": [null, {"qty": -1}]
+			}`)
+			So(fields(errs), ShouldContain, "shipping.address.zip")
+			So(fields(errs), ShouldContain, "shipping.address")
+			So(fields(errs), ShouldContain, "items[1].qty")
+			So(fields(errs), ShouldContain, "notes[1].qty")
+			So(errs.Len(), ShouldEqual, 4)
+		})
+
+		Convey("A required slice of FieldMappers should not be empty", func() {
+			errs := bind(`{"shipping": {"address": {"zip": "12345"}}, "items": []}`)
+			So(fields(errs), ShouldResemble, []string{"items"})
+			So(errs.Has(RequiredError), ShouldBeTrue)
+		})
+
+		Convey("Nested structs absent from the request should not be validated", func() {
+			errs := bind(`{"items": [{"qty": 2}]}`)
+			So(errs, ShouldBeEmpty)
+		})
+
+		Convey("Nested keys should match the fields case-insensitively", func() {
+			errs := bind(`{"Shipping": {"ADDRESS": {"Zip": "", "Country": "XX"}}, "items": [{"qty": 1}]}`)
+			So(fields(errs), ShouldResemble, []string{"shipping.address", "shipping.address.zip"})
+		})
+	})
+
+	Convey("Given a form request for a struct with nested FieldMappers", t, func() {
+		req, err := http.NewRequest("GET", "http://www.example.com/", nil)
+		So(err, ShouldBeNil)
+		model := &OrderModel{Items: []LineItem{{Qty: 0}}}
+
+		Convey("The nested values should still be validated", func() {
+			errs, _ := Bind(req, model).(Errors)
+			So(errs.Len(), ShouldEqual, 2)
+			So(errs[0].Fields(), ShouldResemble, []string{"items[0].qty"})
+			So(errs[1].Fields(), ShouldResemble, []string{"shipping.address.zip"})
+			So(errs[1].Kind(), ShouldEqual, RequiredError)
+		})
+	})
+}
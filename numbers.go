@@ -0,0 +1,180 @@
+package binding
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// rangeError reports a number that does not fit the field's type.
+type rangeError struct {
+	value    string
+	min, max string
+}
+
+func (e *rangeError) Error() string {
+	return fmt.Sprintf("%q is out of range; must be between %s and %s", e.value, e.min, e.max)
+}
+
+func (e *rangeError) Unwrap() error {
+	return strconv.ErrRange
+}
+
+// parseInt parses a signed integer of the given bit size according to
+// the number format options of fieldSpec.
+func parseInt(str string, bitSize int, fieldSpec Field) (int64, error) {
+	s, err := fieldSpec.normalizeNumber(str, false)
+	if err != nil {
+		return 0, err
+	}
+	digits, base := fieldSpec.numberBase(s)
+	val, err := strconv.ParseInt(digits, base, bitSize)
+	if errors.Is(err, strconv.ErrRange) {
+		if bitSize == 0 {
+			bitSize = strconv.IntSize
+		}
+		return 0, &rangeError{
+			value: str,
+			min:   strconv.FormatInt(-1<<(bitSize-1), 10),
+			max:   strconv.FormatInt(1<<(bitSize-1)-1, 10),
+		}
+	}
+	return val, withNum(err, s)
+}
+
+// parseUint parses an unsigned integer of the given bit size according
+// to the number format options of fieldSpec.
+func parseUint(str string, bitSize int, fieldSpec Field) (uint64, error) {
+	s, err := fieldSpec.normalizeNumber(str, false)
+	if err != nil {
+		return 0, err
+	}
+	digits, base := fieldSpec.numberBase(s)
+	val, err := strconv.ParseUint(digits, base, bitSize)
+	if errors.Is(err, strconv.ErrRange) {
+		if bitSize == 0 {
+			bitSize = strconv.IntSize
+		}
+		return 0, &rangeError{
+			value: str,
+			min:   "0",
+			max:   strconv.FormatUint(1<<uint(bitSize)-1, 10),
+		}
+	}
+	return val, withNum(err, s)
+}
+
+// parseFloat parses a floating-point number of the given bit size
+// according to the number format options of fieldSpec.
+func parseFloat(str string, bitSize int, fieldSpec Field) (float64, error) {
+	s, err := fieldSpec.normalizeNumber(str, true)
+	if err != nil {
+		return 0, err
+	}
+	val, err := strconv.ParseFloat(s, bitSize)
+	if errors.Is(err, strconv.ErrRange) && math.IsInf(val, 0) {
+		max := math.MaxFloat64
+		if bitSize == 32 {
+			max = math.MaxFloat32
+		}
+		return 0, &rangeError{
+			value: str,
+			min:   strconv.FormatFloat(-max, 'g', -1, bitSize),
+			max:   strconv.FormatFloat(max, 'g', -1, bitSize),
+		}
+	}
+	if err != nil {
+		return 0, err
+	}
+	if fieldSpec.Finite && (math.IsNaN(val) || math.IsInf(val, 0)) {
+		return 0, fmt.Errorf("%q is not a finite number", str)
+	}
+	return val, nil
+}
+
+// numberBase returns s without the 0x, 0o or 0b prefix that f allows,
+// and the base to parse the rest in. Unlike strconv with base 0, a
+// leading 0 alone does not make s octal.
+func (f Field) numberBase(s string) (string, int) {
+	if !f.NumberPrefixes {
+		return s, 10
+	}
+	sign, digits := "", s
+	if len(s) > 0 && (s[0] == '+' || s[0] == '-') {
+		sign, digits = s[:1], s[1:]
+	}
+	if len(digits) < 3 || digits[0] != '0' || digits[2] == '+' || digits[2] == '-' {
+		return s, 10
+	}
+	switch digits[1] {
+	case 'x', 'X':
+		return sign + digits[2:], 16
+	case 'o', 'O':
+		return sign + digits[2:], 8
+	case 'b', 'B':
+		return sign + digits[2:], 2
+	}
+	return s, 10
+}
+
+// withNum returns err with s as the input it reports, if err is a
+// *strconv.NumError, so that it doesn't lose a prefix removed by
+// numberBase.
+func withNum(err error, s string) error {
+	if numErr, ok := err.(*strconv.NumError); ok {
+		numErr.Num = s
+	}
+	return err
+}
+
+// normalizeNumber removes the digit separators allowed by f from str
+// and, for floats, replaces the decimal separator with a period.
+func (f Field) normalizeNumber(str string, float bool) (string, error) {
+	s := str
+
+	if f.Underscores {
+		var b strings.Builder
+		for i, r := range s {
+			if r == '_' {
+				if i == 0 || i == len(s)-1 || !isAlnum(s[i-1]) || !isAlnum(s[i+1]) {
+					return "", fmt.Errorf("%q has a misplaced underscore", str)
+				}
+				continue
+			}
+			b.WriteRune(r)
+		}
+		s = b.String()
+	}
+
+	if f.ThousandsSeparator != "" && strings.Contains(s, f.ThousandsSeparator) {
+		intPart, rest := s, ""
+		if float {
+			decimal := "."
+			if f.DecimalSeparator != "" {
+				decimal = f.DecimalSeparator
+			}
+			if i := strings.Index(s, decimal); i >= 0 {
+				intPart, rest = s[:i], s[i:]
+			}
+		}
+		groups := strings.Split(strings.TrimLeft(intPart, "+-"), f.ThousandsSeparator)
+		for i, g := range groups {
+			if (i == 0 && (len(g) == 0 || len(g) > 3)) || (i > 0 && len(g) != 3) {
+				return "", fmt.Errorf("%q has misplaced thousands separators", str)
+			}
+		}
+		s = strings.Replace(intPart, f.ThousandsSeparator, "", -1) + rest
+	}
+
+	if float && f.DecimalSeparator != "" {
+		s = strings.Replace(s, f.DecimalSeparator, ".", 1)
+	}
+
+	return s, nil
+}
+
+func isAlnum(c byte) bool {
+	return '0' <= c && c <= '9' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
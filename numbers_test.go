@@ -0,0 +1,109 @@
+package binding
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestNumberParsing(t *testing.T) {
+	Convey("Given a field with no number options", t, func() {
+		f := Field{}
+
+		Convey("Integers should be parsed in base 10 only", func() {
+			_, err := parseInt("0x1f", 64, f)
+			So(err, ShouldNotBeNil)
+			v, err := parseInt("010", 64, f)
+			So(err, ShouldBeNil)
+			So(v, ShouldEqual, 10)
+		})
+
+		Convey("Integers out of range should produce a range error", func() {
+			_, err := parseInt("128", 8, f)
+			So(err, ShouldHaveSameTypeAs, &rangeError{})
+			So(err.Error(), ShouldContainSubstring, "between -128 and 127")
+			_, err = parseUint("70000", 16, f)
+			So(err.Error(), ShouldContainSubstring, "between 0 and 65535")
+		})
+
+		Convey("A float32 overflow should produce a range error", func() {
+			_, err := parseFloat("1e39", 32, f)
+			So(err, ShouldHaveSameTypeAs, &rangeError{})
+			_, err = parseFloat("1e400", 64, f)
+			So(err, ShouldHaveSameTypeAs, &rangeError{})
+		})
+
+		Convey("Non-finite floats should be accepted", func() {
+			v, err := parseFloat("NaN", 64, f)
+			So(err, ShouldBeNil)
+			So(math.IsNaN(v), ShouldBeTrue)
+		})
+	})
+
+	Convey("Given a field with number options", t, func() {
+		Convey("NumberPrefixes should allow hexadecimal, octal and binary", func() {
+			f := Field{NumberPrefixes: true}
+			for str, expected := range map[string]int64{"0x1f": 31, "0O17": 15, "0b101": 5, "-0x10": -16, "010": 10, "0": 0} {
+				v, err := parseInt(str, 64, f)
+				So(err, ShouldBeNil)
+				So(v, ShouldEqual, expected)
+			}
+			u, err := parseUint("010", 0, f)
+			So(err, ShouldBeNil)
+			So(u, ShouldEqual, 10)
+			for _, str := range []string{"0x-5", "0x", "0x1_f", "0b2"} {
+				_, err := parseInt(str, 64, f)
+				So(err, ShouldNotBeNil)
+			}
+			var numErr *strconv.NumError
+			_, err = parseInt("0xzz", 64, f)
+			So(errors.As(err, &numErr), ShouldBeTrue)
+			So(numErr.Num, ShouldEqual, "0xzz")
+		})
+
+		Convey("Underscores should be allowed between digits", func() {
+			f := Field{Underscores: true}
+			v, err := parseUint("1_000_000", 0, f)
+			So(err, ShouldBeNil)
+			So(v, ShouldEqual, 1000000)
+			_, err = parseUint("1__000", 0, f)
+			So(err, ShouldNotBeNil)
+			_, err = parseUint("_1", 0, f)
+			So(err, ShouldNotBeNil)
+		})
+
+		Convey("Thousands and decimal separators should be honored", func() {
+			f := Field{ThousandsSeparator: ".", DecimalSeparator: ","}
+			v, err := parseFloat("1.234.567,5", 64, f)
+			So(err, ShouldBeNil)
+			So(v, ShouldEqual, 1234567.5)
+			_, err = parseFloat("12.34,5", 64, f)
+			So(err, ShouldNotBeNil)
+			i, err := parseInt("-1.000", 64, f)
+			So(err, ShouldBeNil)
+			So(i, ShouldEqual, -1000)
+		})
+
+		Convey("Finite should reject NaN and infinities", func() {
+			f := Field{Finite: true}
+			for _, str := range []string{"NaN", "Inf", "-Infinity"} {
+				_, err := parseFloat(str, 64, f)
+				So(err, ShouldNotBeNil)
+			}
+		})
+	})
+
+	Convey("Given a form value out of range for its field", t, func() {
+		req, err := http.NewRequest("POST", "http://www.example.com", nil)
+		So(err, ShouldBeNil)
+		errs := bindForm(req, new(AllTypes), map[string][]string{"int8Slice": {"1", "300"}}, nil)
+
+		Convey("A RangeError should be produced", func() {
+			So(errs.Has(RangeError), ShouldBeTrue)
+		})
+	})
+}
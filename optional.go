@@ -0,0 +1,122 @@
+package binding
+
+import (
+	"encoding/json"
+	"mime/multipart"
+)
+
+// Optional holds a value of type T along with whether it was present
+// in the request and whether it was explicitly null. It lets you tell
+// apart a field that was left out, one that was sent as null (or with
+// the form NullToken), and one that was sent as the zero value, which
+// is usually what PATCH endpoints need.
+//
+// T may be any type supported by form deserialization, or a type
+// implementing Binder. In JSON requests, T is decoded by encoding/json.
+type Optional[T any] struct {
+	// Value is the deserialized value. It is the zero value of T
+	// when the field is absent or null.
+	Value T
+
+	// Present reports whether the field appeared in the request.
+	Present bool
+
+	// Null reports whether the field was explicitly null.
+	Null bool
+}
+
+// Get returns the value and whether it is set, that is, present
+// and not null.
+func (o Optional[T]) Get() (T, bool) {
+	return o.Value, o.Present && !o.Null
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It is only called for
+// keys that are present in the JSON object.
+func (o *Optional[T]) UnmarshalJSON(data []byte) error {
+	var zero T
+	o.Present = true
+	if string(data) == "null" {
+		o.Null = true
+		o.Value = zero
+		return nil
+	}
+	o.Null = false
+	o.Value = zero
+	return json.Unmarshal(data, &o.Value)
+}
+
+// MarshalJSON implements json.Marshaler. A field that is absent or
+// null is encoded as null.
+func (o Optional[T]) MarshalJSON() ([]byte, error) {
+	if !o.Present || o.Null {
+		return []byte("null"), nil
+	}
+	return json.Marshal(o.Value)
+}
+
+func (o *Optional[T]) bindForm(fieldSpec Field, strs []string, files []*multipart.FileHeader) Errors {
+	var zero T
+
+	if len(strs) == 0 && len(files) == 0 {
+		return nil
+	}
+	o.Present = true
+	o.Value = zero
+
+	nullToken := NullToken
+	if fieldSpec.NullToken != "" {
+		nullToken = fieldSpec.NullToken
+	}
+	if len(files) == 0 && strs[0] == nullToken {
+		o.Null = true
+		return nil
+	}
+	o.Null = false
+	return o.bindValue(fieldSpec, strs, files)
+}
+
+// bindDefault stores the Default of fieldSpec in o.Value. Present and
+// Null are left alone, as the value did not come from the request.
+func (o *Optional[T]) bindDefault(fieldSpec Field) Errors {
+	var zero T
+	o.Value = zero
+	return o.bindValue(fieldSpec, []string{fieldSpec.Default}, nil)
+}
+
+func (o *Optional[T]) bindValue(fieldSpec Field, strs []string, files []*multipart.FileHeader) Errors {
+	if binder, ok := interface{}(&o.Value).(Binder); ok {
+		var errs Errors
+		err := binder.Bind(fieldSpec.Form, strs)
+		if err != nil {
+			errs.addErr([]string{fieldSpec.Form}, err)
+		}
+		return errs
+	}
+
+	return bindValue(&o.Value, fieldSpec, strs, files)
+}
+
+func (o *Optional[T]) missing() bool {
+	return !o.Present || o.Null
+}
+
+func (o *Optional[T]) snapshot() func() {
+	return keep(o)
+}
+
+func (o *Optional[T]) valuePointer() interface{} {
+	if o.missing() {
+		return nil
+	}
+	return &o.Value
+}
+
+// optionalValue is implemented by *Optional[T] for every T.
+type optionalValue interface {
+	bindForm(Field, []string, []*multipart.FileHeader) Errors
+	bindDefault(Field) Errors
+	missing() bool
+	snapshot() func()
+	valuePointer() interface{}
+}
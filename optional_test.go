@@ -0,0 +1,93 @@
+package binding
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type OptionalModel struct {
+	Age   Optional[int]
+	Name  Optional[string]
+	Email Optional[*string]
+}
+
+func (m *OptionalModel) FieldMap(req *http.Request) FieldMap {
+	return FieldMap{
+		&m.Age:   Field{Form: "age", NullToken: "null"},
+		&m.Name:  Field{Form: "name", Required: true},
+		&m.Email: "email",
+	}
+}
+
+func TestOptional(t *testing.T) {
+	Convey("Given a struct with Optional fields", t, func() {
+		req, err := http.NewRequest("POST", "http://www.example.com", nil)
+		So(err, ShouldBeNil)
+		model := new(OptionalModel)
+
+		Convey("When a field is absent", func() {
+			errs := bindForm(req, model, map[string][]string{"name": {"x"}}, nil)
+			So(errs, ShouldBeEmpty)
+			So(model.Age.Present, ShouldBeFalse)
+			So(model.Email.Present, ShouldBeFalse)
+		})
+
+		Convey("When a field is the null token", func() {
+			errs := bindForm(req, model, map[string][]string{"name": {"x"}, "age": {"null"}, "email": {""}}, nil)
+			So(errs, ShouldBeEmpty)
+			So(model.Age.Present, ShouldBeTrue)
+			So(model.Age.Null, ShouldBeTrue)
+			So(model.Email.Present, ShouldBeTrue)
+			So(model.Email.Null, ShouldBeTrue)
+		})
+
+		Convey("When a field is the zero value", func() {
+			errs := bindForm(req, model, map[string][]string{"name": {"x"}, "age": {"0"}, "email": {"a@b.c"}}, nil)
+			So(errs, ShouldBeEmpty)
+			So(model.Age.Present, ShouldBeTrue)
+			So(model.Age.Null, ShouldBeFalse)
+			So(model.Age.Value, ShouldEqual, 0)
+			So(*model.Email.Value, ShouldEqual, "a@b.c")
+		})
+
+		Convey("When a field has the wrong type", func() {
+			errs := bindForm(req, model, map[string][]string{"name": {"x"}, "age": {"old"}}, nil)
+			So(errs.Has(TypeError), ShouldBeTrue)
+		})
+
+		Convey("When a required field is null", func() {
+			errs := bindForm(req, model, map[string][]string{"name": {""}}, nil)
+			So(errs.Has(RequiredError), ShouldBeTrue)
+		})
+
+		Convey("When a required field is null with RequirePresence", func() {
+			req, err := http.NewRequest("GET", "http://www.example.com/?name=", nil)
+			So(err, ShouldBeNil)
+			errs, _ := URL(req, model, RequirePresence()).(Errors)
+			So(errs.Has(RequiredError), ShouldBeTrue)
+		})
+	})
+
+	Convey("Given a JSON request with Optional fields", t, func() {
+		data := `{"age": 0, "name": null}`
+		req, err := http.NewRequest("POST", "http://www.example.com", strings.NewReader(data))
+		So(err, ShouldBeNil)
+		model := new(OptionalModel)
+		errs := defaultJsonBinder(req, model)
+
+		Convey("Presence and null-ness should be recorded", func() {
+			So(model.Age.Present, ShouldBeTrue)
+			So(model.Age.Null, ShouldBeFalse)
+			So(model.Name.Present, ShouldBeTrue)
+			So(model.Name.Null, ShouldBeTrue)
+			So(model.Email.Present, ShouldBeFalse)
+		})
+
+		Convey("A null required field should produce an error", func() {
+			So(errs.Has(RequiredError), ShouldBeTrue)
+		})
+	})
+}
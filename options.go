@@ -0,0 +1,107 @@
+package binding
+
+import (
+	"context"
+	"net/http"
+)
+
+// An Option configures a single call to Bind, Form, URL, MultipartForm,
+// Json or Validate.
+type Option func(*options)
+
+type options struct {
+	// presence, if not nil, receives the set of fields that were
+	// present in the request.
+	presence *Fields
+
+	// requirePresence makes Required mean "present in the request"
+	// rather than "not the zero value".
+	requirePresence bool
+
+	// atomic restores the struct's fields if binding fails.
+	atomic bool
+
+	// groups are the active validation groups.
+	groups []string
+
+	// workers, if positive, overrides ValidatorWorkers.
+	workers int
+
+	// strict reports request keys that are not in the FieldMap,
+	// except those matching a pattern in allow.
+	strict bool
+	allow  []string
+
+	// locales are the preferred locales for messages.
+	locales []string
+}
+
+// RecordPresence stores the set of fields that were present in the
+// request into fields, so that a handler can tell which fields the
+// client actually sent. This works for every request source.
+func RecordPresence(fields *Fields) Option {
+	return func(o *options) {
+		o.presence = fields
+	}
+}
+
+// RequirePresence makes Required fields fail only when they are absent
+// from the request, so that a field explicitly set to its zero value
+// (0, false or "") is accepted. When there is no request data to go by,
+// as with Validate, Required falls back to checking for the zero value.
+func RequirePresence() Option {
+	return func(o *options) {
+		o.requirePresence = true
+	}
+}
+
+// bindState is the state of a single call into the package. It travels
+// with the request's context so that it reaches the request binders.
+type bindState struct {
+	options
+
+	// fields is the set of fields present in the request; it is nil
+	// until request data has been read.
+	fields Fields
+
+	// json reports whether fields came from a JSON body, whose nested
+	// keys are matched to Form names as encoding/json matches them to
+	// struct fields.
+	json bool
+}
+
+type stateKey struct{}
+
+// run invokes binder with a request that carries the state built from
+// opts, and reports the results back to the caller.
+func run(binder requestBinder, req *http.Request, userStruct FieldMapper, opts []Option) error {
+	st := new(bindState)
+	for _, opt := range opts {
+		opt(&st.options)
+	}
+
+	r := req.WithContext(context.WithValue(req.Context(), stateKey{}, st))
+	errs := binder(r, userStruct)
+
+	// The binders parse the request's form into the copy; keep it.
+	req.Form, req.PostForm, req.MultipartForm = r.Form, r.PostForm, r.MultipartForm
+
+	if st.presence != nil {
+		*st.presence = st.fields
+	}
+
+	if len(errs) > 0 {
+		return localize(errs, st.catalog(req))
+	}
+	return nil
+}
+
+// withState returns the state carried by req, attaching a new one to
+// a copy of req if there is none.
+func withState(req *http.Request) (*http.Request, *bindState) {
+	if st, ok := req.Context().Value(stateKey{}).(*bindState); ok {
+		return req, st
+	}
+	st := new(bindState)
+	return req.WithContext(context.WithValue(req.Context(), stateKey{}, st)), st
+}
@@ -0,0 +1,147 @@
+package binding
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+type (
+	// FieldList declares the fields of a struct in order, as Field
+	// values with their Target set, along with any Rules. Fields are
+	// bound and validated in the order they are listed, and errors are
+	// returned in that order.
+	FieldList []interface{}
+
+	// FieldLister can be implemented by a FieldMapper to declare its
+	// fields in order. Its FieldMap method can simply return the
+	// FieldList converted by FieldList.FieldMap:
+	//
+	//	func (f *ContactForm) FieldMap(req *http.Request) binding.FieldMap {
+	//		return f.FieldList(req).FieldMap()
+	//	}
+	FieldLister interface {
+		FieldList(*http.Request) FieldList
+	}
+)
+
+// listKey is the FieldMap key of a Rule converted from a FieldList.
+type listKey int
+
+// FieldMap converts l to a FieldMap.
+func (l FieldList) FieldMap() FieldMap {
+	fm := make(FieldMap, len(l))
+	for i, entry := range l {
+		switch e := entry.(type) {
+		case Field:
+			if e.Target != nil {
+				fm[e.Target] = e
+			}
+		case Rule:
+			fm[listKey(i)] = e
+		}
+	}
+	return fm
+}
+
+// fields returns the Fields of l that have a Target.
+func (l FieldList) fields() []Field {
+	var fields []Field
+	for _, entry := range l {
+		if f, ok := entry.(Field); ok && f.Target != nil {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+// rules returns the Rules of l.
+func (l FieldList) rules() []Rule {
+	var rules []Rule
+	for _, entry := range l {
+		if r, ok := entry.(Rule); ok {
+			rules = append(rules, r)
+		}
+	}
+	return rules
+}
+
+// fieldList returns the fields of userStruct in order: as declared if
+// it is a FieldLister, or else sorted by form name, followed by its
+// Rules sorted by key.
+func fieldList(req *http.Request, userStruct FieldMapper) FieldList {
+	if lister, ok := userStruct.(FieldLister); ok {
+		l := lister.FieldList(req)
+		mustKnowFormats(l)
+		return l
+	}
+
+	type keyedRule struct {
+		key  string
+		rule Rule
+	}
+	var fields []Field
+	var rules []keyedRule
+	for fieldPointer, fieldNameOrSpec := range userStruct.FieldMap(req) {
+		if rule, ok := fieldNameOrSpec.(Rule); ok {
+			rules = append(rules, keyedRule{fmt.Sprint(fieldPointer), rule})
+			continue
+		}
+		fieldSpec, err := fieldSpecification(fieldNameOrSpec)
+		if err != nil {
+			continue
+		}
+		fieldSpec.Target = fieldPointer
+		fields = append(fields, fieldSpec)
+	}
+
+	sort.Slice(fields, func(i, j int) bool {
+		return fields[i].Form < fields[j].Form
+	})
+	sort.Slice(rules, func(i, j int) bool {
+		return rules[i].key < rules[j].key
+	})
+
+	l := make(FieldList, 0, len(fields)+len(rules))
+	for _, f := range fields {
+		l = append(l, f)
+	}
+	for _, r := range rules {
+		l = append(l, r.rule)
+	}
+	mustKnowFormats(l)
+	return l
+}
+
+// sortErrors orders errs by the position in l of the first field they
+// name. Errors about the request as a whole come first, and errors
+// about fields not in l come last.
+func sortErrors(errs Errors, l FieldList) {
+	position := make(map[string]int)
+	for i, f := range l.fields() {
+		if _, ok := position[f.Form]; !ok {
+			position[f.Form] = i
+		}
+	}
+
+	rank := func(e Error) int {
+		if len(e.Fields()) == 0 {
+			return -1
+		}
+		name := e.Fields()[0]
+		if i := strings.IndexAny(name, ".["); i > 0 {
+			if _, ok := position[name]; !ok {
+				name = name[:i]
+			}
+		}
+		if i, ok := position[name]; ok {
+			return i
+		}
+		return len(position)
+	}
+
+	sort.SliceStable(errs, func(i, j int) bool {
+		return rank(errs[i]) < rank(errs[j])
+	})
+}
@@ -0,0 +1,83 @@
+package binding
+
+import (
+	"net/http"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type CheckoutForm struct {
+	Zip   string
+	Email string
+	Age   int
+	Card  string
+	IBAN  string
+}
+
+func (f *CheckoutForm) FieldList(req *http.Request) FieldList {
+	return FieldList{
+		Field{Target: &f.Zip, Form: "zip", Required: true},
+		Field{Target: &f.Email, Form: "email", Format: FormatEmail},
+		Field{Target: &f.Age, Form: "age", Min: 18},
+		Field{Target: &f.Card, Form: "card"},
+		Field{Target: &f.IBAN, Form: "iban"},
+		ExactlyOneOf("card", "iban"),
+	}
+}
+
+func (f *CheckoutForm) FieldMap(req *http.Request) FieldMap {
+	return f.FieldList(req).FieldMap()
+}
+
+type UnorderedForm struct {
+	A, B, C, D int
+}
+
+func (f *UnorderedForm) FieldMap(req *http.Request) FieldMap {
+	return FieldMap{
+		&f.D: Field{Form: "d", Required: true},
+		&f.C: Field{Form: "c", Required: true},
+		&f.B: Field{Form: "b", Required: true},
+		&f.A: Field{Form: "a", Required: true},
+	}
+}
+
+func TestFieldOrder(t *testing.T) {
+	Convey("Given a struct that lists its fields in order", t, func() {
+		req, err := http.NewRequest("POST", "http://www.example.com", nil)
+		So(err, ShouldBeNil)
+
+		Convey("Errors should come back in declaration order", func() {
+			errs := bindForm(req, new(CheckoutForm), map[string][]string{
+				"email": {"nope"},
+				"age":   {"x"},
+			}, nil)
+			var fields []string
+			for _, e := range errs {
+				fields = append(fields, e.Fields()[0])
+			}
+			So(fields, ShouldResemble, []string{"zip", "email", "age", "card"})
+		})
+
+		Convey("Its FieldMap should hold the same fields and rules", func() {
+			fm := new(CheckoutForm).FieldMap(req)
+			So(len(fm), ShouldEqual, 6)
+		})
+	})
+
+	Convey("Given a plain FieldMap", t, func() {
+		req, err := http.NewRequest("POST", "http://www.example.com", nil)
+		So(err, ShouldBeNil)
+
+		Convey("Errors should be sorted by field name every time", func() {
+			for i := 0; i < 10; i++ {
+				errs := bindForm(req, new(UnorderedForm), map[string][]string{}, nil)
+				So(errs.Len(), ShouldEqual, 4)
+				for j, e := range errs {
+					So(e.Fields(), ShouldResemble, []string{string(rune('a' + j))})
+				}
+			}
+		})
+	})
+}
@@ -0,0 +1,96 @@
+package binding
+
+import (
+	"strconv"
+	"strings"
+)
+
+// A Path locates a value in a request as a sequence of object keys and
+// array indexes, such as items, 3, price. Unlike a field name, it is
+// unambiguous when keys contain dots or brackets.
+type Path []PathSegment
+
+// A PathSegment is an object key or, if IsIndex is set, an array index.
+type PathSegment struct {
+	Key     string
+	Index   int
+	IsIndex bool
+}
+
+// KeySegment returns the PathSegment for an object key.
+func KeySegment(key string) PathSegment {
+	return PathSegment{Key: key}
+}
+
+// IndexSegment returns the PathSegment for an array index.
+func IndexSegment(i int) PathSegment {
+	return PathSegment{Index: i, IsIndex: true}
+}
+
+// String returns p in the form of the names of Error.Fields, with keys
+// joined by dots and indexes in brackets, as in "items[3].price".
+func (p Path) String() string {
+	var b strings.Builder
+	for i, s := range p {
+		switch {
+		case s.IsIndex:
+			b.WriteString("[" + strconv.Itoa(s.Index) + "]")
+		case i > 0:
+			b.WriteString("." + s.Key)
+		default:
+			b.WriteString(s.Key)
+		}
+	}
+	return b.String()
+}
+
+// Pointer returns p as a JSON Pointer (RFC 6901), as in "/items/3/price".
+func (p Path) Pointer() string {
+	var b strings.Builder
+	for _, s := range p {
+		b.WriteString("/")
+		if s.IsIndex {
+			b.WriteString(strconv.Itoa(s.Index))
+		} else {
+			b.WriteString(pointerEscaper.Replace(s.Key))
+		}
+	}
+	return b.String()
+}
+
+var pointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+// Dot returns p in dot notation, as in "items.3.price".
+func (p Path) Dot() string {
+	segments := make([]string, len(p))
+	for i, s := range p {
+		if s.IsIndex {
+			segments[i] = strconv.Itoa(s.Index)
+		} else {
+			segments[i] = s.Key
+		}
+	}
+	return strings.Join(segments, ".")
+}
+
+// Bracket returns p in bracket notation, as in "items[3][price]".
+func (p Path) Bracket() string {
+	var b strings.Builder
+	for i, s := range p {
+		switch {
+		case s.IsIndex:
+			b.WriteString("[" + strconv.Itoa(s.Index) + "]")
+		case i > 0:
+			b.WriteString("[" + s.Key + "]")
+		default:
+			b.WriteString(s.Key)
+		}
+	}
+	return b.String()
+}
+
+// join returns p followed by the segments of q, in a new Path.
+func (p Path) join(q Path) Path {
+	joined := make(Path, 0, len(p)+len(q))
+	return append(append(joined, p...), q...)
+}
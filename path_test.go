@@ -0,0 +1,114 @@
+package binding
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type ProfileForm struct {
+	Name string
+}
+
+func (f *ProfileForm) FieldMap(req *http.Request) FieldMap {
+	return FieldMap{
+		&f.Name: Field{Form: "user.name", Required: true},
+	}
+}
+
+type ContactForm struct {
+	Email   string       `json:"emailAddress"`
+	Address AddressModel `json:"homeAddress"`
+}
+
+func (f *ContactForm) FieldMap(req *http.Request) FieldMap {
+	return FieldMap{
+		&f.Email:   Field{Form: "email", Required: true},
+		&f.Address: "address",
+	}
+}
+
+func TestPath(t *testing.T) {
+	Convey("Given a path with keys and an index", t, func() {
+		p := Path{KeySegment("items"), IndexSegment(3), KeySegment("price")}
+
+		Convey("It should render in every notation", func() {
+			So(p.String(), ShouldEqual, "items[3].price")
+			So(p.Pointer(), ShouldEqual, "/items/3/price")
+			So(p.Dot(), ShouldEqual, "items.3.price")
+			So(p.Bracket(), ShouldEqual, "items[3][price]")
+		})
+
+		Convey("JSON Pointers should escape ~ and /", func() {
+			So(Path{KeySegment("a/b"), KeySegment("~c")}.Pointer(), ShouldEqual, "/a~1b/~0c")
+		})
+
+		Convey("An empty path should point to the whole document", func() {
+			So(Path{}.Pointer(), ShouldEqual, "")
+		})
+	})
+
+	Convey("Given a request with errors in nested values", t, func() {
+		req, err := http.NewRequest("POST", "http://www.example.com",
+			strings.NewReader(`{"shipping": {"address": {"zip": "", "country": "XX"}}, "items": [{"qty": 1}, {"qty": 0}]}`))
+		So(err, ShouldBeNil)
+		req.Header.Set("Content-Type", "application/json")
+		errs, _ := Bind(req, new(OrderModel)).(Errors)
+
+		var pointers []string
+		for _, e := range errs {
+			for _, p := range e.(LocatedError).Paths() {
+				pointers = append(pointers, p.Pointer())
+			}
+		}
+
+		Convey("The errors should locate their fields precisely", func() {
+			So(pointers, ShouldResemble, []string{
+				"/items/1/qty",
+				"/shipping/address",
+				"/shipping/address/zip",
+			})
+		})
+
+		Convey("The errors should encode their JSON Pointers", func() {
+			b, err := json.Marshal(errs[0])
+			So(err, ShouldBeNil)
+			So(string(b), ShouldContainSubstring, `"fieldNames":["items[1].qty"],"pointers":["/items/1/qty"]`)
+		})
+	})
+
+	Convey("Given a JSON body whose keys differ from the form names", t, func() {
+		req, err := http.NewRequest("POST", "http://www.example.com",
+			strings.NewReader(`{"emailAddress": "", "homeAddress": {"zip": ""}}`))
+		So(err, ShouldBeNil)
+		req.Header.Set("Content-Type", "application/json")
+		errs, _ := Bind(req, new(ContactForm)).(Errors)
+
+		Convey("The pointers should locate the JSON keys", func() {
+			var fields, pointers []string
+			for _, e := range errs {
+				fields = append(fields, e.Fields()...)
+				for _, p := range e.(LocatedError).Paths() {
+					pointers = append(pointers, p.Pointer())
+				}
+			}
+			So(fields, ShouldResemble, []string{"address.zip", "email"})
+			So(pointers, ShouldResemble, []string{"/homeAddress/zip", "/emailAddress"})
+		})
+	})
+
+	Convey("Given a form field whose name contains a dot", t, func() {
+		req, err := http.NewRequest("GET", "http://www.example.com/", nil)
+		So(err, ShouldBeNil)
+		errs, _ := Bind(req, new(ProfileForm)).(Errors)
+		So(errs.Len(), ShouldEqual, 1)
+
+		Convey("The path should be the whole form key", func() {
+			So(errs[0].(LocatedError).Paths(), ShouldResemble, []Path{{KeySegment("user.name")}})
+			So(errs[0].(LocatedError).Paths()[0].Pointer(), ShouldEqual, "/user.name")
+		})
+	})
+}
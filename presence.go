@@ -0,0 +1,106 @@
+package binding
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime/multipart"
+	"strconv"
+	"strings"
+)
+
+// Fields is the set of names of the fields present in a request. For
+// JSON requests, the keys of nested objects are joined with a dot, and
+// array elements are addressed as name[i]. A top-level JSON key is
+// recorded under the Form name of the field it is decoded into, which
+// encoding/json matches case-insensitively.
+type Fields map[string]struct{}
+
+// Has reports whether the field with the given name was present.
+func (f Fields) Has(name string) bool {
+	_, ok := f[name]
+	return ok
+}
+
+func (st *bindState) recordForm(formData map[string][]string, formFile map[string][]*multipart.FileHeader) {
+	if st.fields == nil {
+		st.fields = make(Fields)
+	}
+	for name := range formData {
+		st.fields[name] = struct{}{}
+	}
+	for name := range formFile {
+		st.fields[name] = struct{}{}
+	}
+}
+
+func (st *bindState) recordJSON(body []byte, keys jsonKeys) {
+	if st.fields == nil {
+		st.fields = make(Fields)
+	}
+	st.json = true
+	if len(bytes.TrimSpace(body)) == 0 {
+		return
+	}
+	var obj map[string]json.RawMessage
+	if json.Unmarshal(body, &obj) != nil {
+		return
+	}
+	fields := make(Fields)
+	fields.recordJSONObject("", obj)
+	for name := range fields.fromJSON(keys) {
+		st.fields[name] = struct{}{}
+	}
+}
+
+// fromJSON returns f with each name, or else its first key, replaced by
+// the Form name of the field that key is decoded into, if any.
+func (f Fields) fromJSON(keys jsonKeys) Fields {
+	if f == nil {
+		return nil
+	}
+	mapped := make(Fields, len(f))
+	for name := range f {
+		if form, ok := keys.form(name); ok {
+			name = form
+		} else if i := strings.IndexAny(name, ".["); i > 0 {
+			if form, ok := keys.form(name[:i]); ok {
+				name = form + name[i:]
+			}
+		}
+		mapped[name] = struct{}{}
+	}
+	return mapped
+}
+
+func (f Fields) recordJSONObject(prefix string, obj map[string]json.RawMessage) {
+	for key, raw := range obj {
+		name := key
+		if prefix != "" {
+			name = prefix + "." + key
+		}
+		f.recordJSONValue(name, raw)
+	}
+}
+
+func (f Fields) recordJSONValue(name string, raw json.RawMessage) {
+	f[name] = struct{}{}
+
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 {
+		return
+	}
+	switch raw[0] {
+	case '{':
+		var obj map[string]json.RawMessage
+		if json.Unmarshal(raw, &obj) == nil {
+			f.recordJSONObject(name, obj)
+		}
+	case '[':
+		var arr []json.RawMessage
+		if json.Unmarshal(raw, &arr) == nil {
+			for i, elem := range arr {
+				f.recordJSONValue(name+"["+strconv.Itoa(i)+"]", elem)
+			}
+		}
+	}
+}
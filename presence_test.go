@@ -0,0 +1,96 @@
+package binding
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type PresenceModel struct {
+	Count int
+	Email string
+}
+
+func (m *PresenceModel) FieldMap(req *http.Request) FieldMap {
+	return FieldMap{
+		&m.Count: Field{Form: "count", Required: true},
+		&m.Email: "email",
+	}
+}
+
+type UntaggedModel struct {
+	Name     string
+	Qty      int
+	Nickname string `json:"nick_name"`
+}
+
+func (m *UntaggedModel) FieldMap(req *http.Request) FieldMap {
+	return FieldMap{
+		&m.Name:     Field{Form: "name", MaxLen: 3},
+		&m.Qty:      Field{Form: "qty", Max: 5},
+		&m.Nickname: "nick",
+	}
+}
+
+func TestPresence(t *testing.T) {
+	Convey("Given a form request", t, func() {
+		data := url.Values{}
+		data.Add("count", "0")
+		req, err := http.NewRequest("POST", "http://www.example.com", strings.NewReader(data.Encode()))
+		So(err, ShouldBeNil)
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		model := new(PresenceModel)
+
+		Convey("The presence set should contain only the fields sent", func() {
+			var fields Fields
+			Bind(req, model, RecordPresence(&fields))
+			So(fields.Has("count"), ShouldBeTrue)
+			So(fields.Has("email"), ShouldBeFalse)
+		})
+
+		Convey("The parsed form should be kept on the request", func() {
+			Bind(req, model)
+			So(req.Form.Get("count"), ShouldEqual, "0")
+		})
+
+		Convey("Required should be satisfied by presence if requested", func() {
+			So(Bind(req, model), ShouldNotBeNil)
+			So(Bind(req, model, RequirePresence()), ShouldBeNil)
+		})
+	})
+
+	Convey("Given a JSON request", t, func() {
+		data := `{"count": 0, "child": {"wibble": "wobble"}, "baz": [1]}`
+		req, err := http.NewRequest("POST", "http://www.example.com", strings.NewReader(data))
+		So(err, ShouldBeNil)
+		req.Header.Set("Content-Type", "application/json")
+
+		Convey("The presence set should contain the keys sent", func() {
+			var fields Fields
+			Bind(req, new(PresenceModel), RecordPresence(&fields), RequirePresence())
+			So(fields.Has("count"), ShouldBeTrue)
+			So(fields.Has("child.wibble"), ShouldBeTrue)
+			So(fields.Has("baz[0]"), ShouldBeTrue)
+			So(fields.Has("email"), ShouldBeFalse)
+		})
+	})
+	Convey("Given a JSON request with keys that differ from the form names", t, func() {
+		data := `{"NAME": "abc", "Qty": 1, "nick_name": "x"}`
+		req, err := http.NewRequest("POST", "http://www.example.com", strings.NewReader(data))
+		So(err, ShouldBeNil)
+		req.Header.Set("Content-Type", "application/json")
+
+		Convey("The keys should be recorded as encoding/json maps them", func() {
+			var fields Fields
+			So(Bind(req, new(UntaggedModel), RecordPresence(&fields)), ShouldBeNil)
+			So(fields.Has("name"), ShouldBeTrue)
+			So(fields.Has("qty"), ShouldBeTrue)
+			So(fields.Has("nick"), ShouldBeTrue)
+			So(fields.Has("NAME"), ShouldBeFalse)
+			So(fields.Has("nick_name"), ShouldBeFalse)
+		})
+	})
+}
@@ -0,0 +1,98 @@
+package binding
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+const problemContentType = "application/problem+json"
+
+// ProblemType is the type URI of the problems written by WriteProblem.
+// With the default, "about:blank", the title of a problem is the
+// standard text of its status code.
+var ProblemType = "about:blank"
+
+// Problem is a problem details object as defined by RFC 9457 (formerly
+// RFC 7807), with the binding errors in an "errors" extension member.
+type Problem struct {
+	Type     string `json:"type,omitempty"`
+	Title    string `json:"title,omitempty"`
+	Status   int    `json:"status,omitempty"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+	Errors   Errors `json:"errors,omitempty"`
+}
+
+// NewProblem returns the Problem for err, which is usually the error
+// returned by Bind or another function of this package. If err is not
+// an Error or Errors, its text becomes the problem's detail.
+func NewProblem(status int, err error) Problem {
+	p := Problem{
+		Type:   ProblemType,
+		Title:  http.StatusText(status),
+		Status: status,
+	}
+
+	switch e := err.(type) {
+	case nil:
+	case Errors:
+		p.Errors = problemErrors(e)
+	case Error:
+		p.Errors = problemErrors(Errors{e})
+	default:
+		p.Detail = e.Error()
+	}
+
+	return p
+}
+
+// Write writes p to w as application/problem+json, with p.Status as
+// the status code.
+func (p Problem) Write(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", problemContentType)
+	w.WriteHeader(p.Status)
+	return json.NewEncoder(w).Encode(p)
+}
+
+// WriteProblem writes err to w as problem details with the given
+// status code; see NewProblem.
+func WriteProblem(w http.ResponseWriter, status int, err error) error {
+	return NewProblem(status, err).Write(w)
+}
+
+// detailedError returns the built-in Error equivalent to d.
+func detailedError(d DetailedError) fieldsError {
+	params := d.Params()
+	if value, ok := d.RejectedValue(); ok && !d.Redacted() {
+		params = make(map[string]interface{}, len(params)+1)
+		for k, v := range d.Params() {
+			params[k] = v
+		}
+		params["value"] = value
+	}
+	return fieldsError{
+		fields:   d.Fields(),
+		kind:     d.Kind(),
+		message:  d.Message(),
+		params:   params,
+		redacted: d.Redacted(),
+		paths:    errorPaths(d),
+	}
+}
+
+// problemErrors returns errs with every Error that does not encode its
+// own JSON replaced by the equivalent built-in Error, so that every
+// element has the same members.
+func problemErrors(errs Errors) Errors {
+	out := make(Errors, len(errs))
+	for i, e := range errs {
+		if _, ok := e.(json.Marshaler); ok {
+			out[i] = e
+		} else if d, ok := e.(DetailedError); ok {
+			out[i] = detailedError(d)
+		} else {
+			out[i] = NewError(e.Fields(), e.Kind(), e.Message())
+		}
+	}
+	return out
+}
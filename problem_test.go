@@ -0,0 +1,63 @@
+package binding
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type plainError struct{}
+
+func (plainError) Error() string    { return "plain" }
+func (plainError) Fields() []string { return []string{"name"} }
+func (plainError) Kind() string     { return "PlainError" }
+func (plainError) Message() string  { return "plain" }
+
+func TestProblem(t *testing.T) {
+	Convey("Given binding errors", t, func() {
+		var errs Errors
+		errs.Add([]string{"email"}, EmailError, "Invalid email address")
+		errs = append(errs, plainError{})
+
+		Convey("WriteProblem should write them as problem details", func() {
+			w := httptest.NewRecorder()
+			So(WriteProblem(w, StatusUnprocessableEntity, errs), ShouldBeNil)
+			So(w.Code, ShouldEqual, StatusUnprocessableEntity)
+			So(w.Header().Get("Content-Type"), ShouldEqual, "application/problem+json")
+
+			var body map[string]interface{}
+			So(json.Unmarshal(w.Body.Bytes(), &body), ShouldBeNil)
+			So(body["type"], ShouldEqual, "about:blank")
+			So(body["title"], ShouldEqual, "Unprocessable Entity")
+			So(body["status"], ShouldEqual, 422)
+			So(body["errors"], ShouldResemble, []interface{}{
+				map[string]interface{}{
+					"fieldNames":     []interface{}{"email"},
+					"pointers":       []interface{}{"/email"},
+					"classification": EmailError,
+					"message":        "Invalid email address",
+				},
+				map[string]interface{}{
+					"fieldNames":     []interface{}{"name"},
+					"pointers":       []interface{}{"/name"},
+					"classification": "PlainError",
+					"message":        "plain",
+				},
+			})
+		})
+	})
+
+	Convey("Given another error", t, func() {
+		p := NewProblem(http.StatusBadRequest, errors.New("unexpected EOF"))
+
+		Convey("Its text should become the detail", func() {
+			So(p.Detail, ShouldEqual, "unexpected EOF")
+			So(p.Errors, ShouldBeNil)
+			So(p.Title, ShouldEqual, "Bad Request")
+		})
+	})
+}
@@ -0,0 +1,107 @@
+package binding
+
+import (
+	"mime/multipart"
+	"net/http"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// Money is a custom Binder type that knows its zero value.
+type Money struct {
+	Cents int64
+	Set   bool
+}
+
+func (m *Money) Bind(fieldName string, strVals []string) error {
+	if len(strVals) > 0 {
+		m.Set = true
+	}
+	return nil
+}
+
+func (m *Money) IsZero() bool {
+	return !m.Set
+}
+
+// Labels is a custom Binder type without an IsZero method.
+type Labels map[string]string
+
+func (l Labels) Bind(fieldName string, strVals []string) error {
+	for _, s := range strVals {
+		if k, v, ok := strings.Cut(s, ":"); ok {
+			l[k] = v
+		}
+	}
+	return nil
+}
+
+type RequiredModel struct {
+	Price   Money
+	Labels  Labels
+	Files   []*multipart.FileHeader
+	Count   int
+	Enabled bool
+}
+
+func (m *RequiredModel) FieldMap(req *http.Request) FieldMap {
+	return FieldMap{
+		&m.Price:   Field{Form: "price", Required: true},
+		&m.Labels:  Field{Form: "labels", Required: true},
+		&m.Files:   Field{Form: "files", Required: true},
+		&m.Count:   Field{Form: "count", Required: true},
+		&m.Enabled: Field{Form: "enabled", Required: true},
+	}
+}
+
+func TestRequired(t *testing.T) {
+	Convey("Given required fields of various types", t, func() {
+		model := &RequiredModel{Labels: make(Labels)}
+		required := func(errs Errors) []string {
+			var names []string
+			for _, e := range errs {
+				if e.Kind() == RequiredError {
+					names = append(names, e.Fields()[0])
+				}
+			}
+			return names
+		}
+
+		Convey("Missing values should produce errors for every type", func() {
+			req, err := http.NewRequest("GET", "http://www.example.com", nil)
+			So(err, ShouldBeNil)
+			errs := bindForm(req, model, map[string][]string{}, nil)
+			So(required(errs), ShouldHaveLength, 5)
+		})
+
+		Convey("Custom types should use IsZero or presence", func() {
+			req, err := http.NewRequest("GET", "http://www.example.com", nil)
+			So(err, ShouldBeNil)
+			errs := bindForm(req, model, map[string][]string{
+				"price":  {"1.00"},
+				"labels": {"a:b"},
+			}, nil)
+			So(required(errs), ShouldNotContain, "price")
+			So(required(errs), ShouldNotContain, "labels")
+			So(required(errs), ShouldContain, "files")
+		})
+
+		Convey("With RequirePresence, explicit zero values should be accepted", func() {
+			req, err := http.NewRequest("GET", "http://www.example.com/?price=0&labels=&files=&count=0&enabled=false", nil)
+			So(err, ShouldBeNil)
+			So(URL(req, model, RequirePresence()), ShouldBeNil)
+		})
+
+		Convey("Without request data, types that can't tell their zero value should pass", func() {
+			model.Price.Set = true
+			model.Count = 1
+			model.Enabled = true
+			req, err := http.NewRequest("GET", "http://www.example.com", nil)
+			So(err, ShouldBeNil)
+			errs := validate(Errors{}, req, model)
+			So(required(errs), ShouldResemble, []string{"files"})
+		})
+	})
+}
@@ -0,0 +1,273 @@
+package binding
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"html/template"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// StatusCodes maps the kinds of Error to the HTTP status codes used by
+// StatusCode and WriteError. Kinds that are not listed, which are the
+// validation failures, get StatusUnprocessableEntity. Change it to use
+// other codes, or add codes for your own kinds.
+var StatusCodes = map[string]int{
+	ContentTypeError:     http.StatusUnsupportedMediaType,
+	DeserializationError: http.StatusBadRequest,
+	TooLargeError:        http.StatusRequestEntityTooLarge,
+}
+
+// ContentTypes are the request Content-Types that Bind supports. They
+// are advertised by WriteError in response to a ContentTypeError.
+var ContentTypes = []string{
+	"application/json",
+	"application/x-www-form-urlencoded",
+	"multipart/form-data",
+}
+
+// StatusCode returns the HTTP status code for err, which is usually the
+// error returned by Bind or another function of this package. It is the
+// code of the first Error whose kind is in StatusCodes, or else
+// StatusUnprocessableEntity. Errors that did not come from binding get
+// http.StatusInternalServerError.
+func StatusCode(err error) int {
+	var errs Errors
+	switch e := err.(type) {
+	case Errors:
+		errs = e
+	case Error:
+		errs = Errors{e}
+	default:
+		return http.StatusInternalServerError
+	}
+
+	for _, e := range errs {
+		if status, ok := StatusCodes[e.Kind()]; ok {
+			return status
+		}
+	}
+	return StatusUnprocessableEntity
+}
+
+// WriteError responds to req with err and the status code given by
+// StatusCode. The response is problem details (see Problem) in JSON or
+// XML, or a short HTML or plain text page, depending on the Accept
+// header of req. For a status of 415, the Accept-Post (for POST),
+// Accept-Patch (for PATCH) or Accept header lists ContentTypes. The text
+// of errors that did not come from binding is not sent to the client.
+func WriteError(w http.ResponseWriter, req *http.Request, err error) error {
+	status := StatusCode(err)
+	if status == http.StatusUnsupportedMediaType {
+		header := "Accept"
+		switch req.Method {
+		case http.MethodPost:
+			header = "Accept-Post"
+		case http.MethodPatch:
+			header = "Accept-Patch"
+		}
+		w.Header().Set(header, strings.Join(ContentTypes, ", "))
+	}
+
+	p := NewProblem(status, err)
+	if status == http.StatusInternalServerError {
+		// Don't show the client the text of other errors.
+		p.Detail = ""
+	}
+	w.Header().Add("Vary", "Accept")
+
+	switch mediaType := negotiate(req.Header.Get("Accept")); mediaType {
+	case "application/json":
+		w.Header().Set("Content-Type", jsonContentType)
+		w.WriteHeader(status)
+		return json.NewEncoder(w).Encode(p)
+	case "application/problem+xml", "application/xml", "text/xml":
+		w.Header().Set("Content-Type", mediaType+"; charset=utf-8")
+		w.WriteHeader(status)
+		return p.writeXML(w)
+	case "text/html":
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(status)
+		return problemHTML.Execute(w, p)
+	case "text/plain":
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.WriteHeader(status)
+		return p.writeText(w)
+	default:
+		return p.Write(w)
+	}
+}
+
+// offers are the media types WriteError can respond with, in order of
+// preference.
+var offers = []string{
+	problemContentType,
+	"application/json",
+	"application/problem+xml",
+	"application/xml",
+	"text/html",
+	"text/plain",
+	"text/xml",
+}
+
+// negotiate returns the offer that best matches the Accept header
+// accept, or the first offer if none does.
+func negotiate(accept string) string {
+	best, bestQ := offers[0], 0.0
+
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		q := 1.0
+		if v, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(v, 64); err != nil {
+				continue
+			}
+		}
+		if q <= bestQ {
+			continue
+		}
+		for _, offer := range offers {
+			if mediaType == offer || mediaType == "*/*" ||
+				(strings.HasSuffix(mediaType, "/*") && strings.HasPrefix(offer, mediaType[:len(mediaType)-1])) {
+				best, bestQ = offer, q
+				break
+			}
+		}
+	}
+
+	return best
+}
+
+// readErrorKind returns the kind of Error for err, an error reading
+// the request body.
+func readErrorKind(err error) string {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) || errors.Is(err, multipart.ErrMessageTooLarge) {
+		return TooLargeError
+	}
+	return DeserializationError
+}
+
+// xmlProblem is the XML form of a Problem, as given in appendix B of
+// RFC 9457. Lists are pointers to wrapper structs, rather than slices
+// tagged "a>b", since encoding/xml writes the element a even when the
+// slice is empty.
+type xmlProblem struct {
+	XMLName  xml.Name   `xml:"urn:ietf:rfc:7807 problem"`
+	Type     string     `xml:"type,omitempty"`
+	Title    string     `xml:"title,omitempty"`
+	Status   int        `xml:"status,omitempty"`
+	Detail   string     `xml:"detail,omitempty"`
+	Instance string     `xml:"instance,omitempty"`
+	Errors   *xmlErrors `xml:"errors,omitempty"`
+}
+
+type xmlErrors struct {
+	Errors []xmlError `xml:"i"`
+}
+
+type xmlError struct {
+	FieldNames     *xmlList   `xml:"fieldNames,omitempty"`
+	Pointers       *xmlList   `xml:"pointers,omitempty"`
+	Classification string     `xml:"classification,omitempty"`
+	Message        string     `xml:"message,omitempty"`
+	Params         *xmlParams `xml:"params,omitempty"`
+	Value          *string    `xml:"value,omitempty"`
+	Redacted       bool       `xml:"redacted,omitempty"`
+}
+
+type xmlList struct {
+	Items []string `xml:"i"`
+}
+
+type xmlParams struct {
+	Params []xmlParam `xml:"param"`
+}
+
+type xmlParam struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:",chardata"`
+}
+
+func (p Problem) writeXML(w http.ResponseWriter) error {
+	x := xmlProblem{
+		Type:     p.Type,
+		Title:    p.Title,
+		Status:   p.Status,
+		Detail:   p.Detail,
+		Instance: p.Instance,
+	}
+	for _, e := range p.Errors {
+		xe := xmlError{Classification: e.Kind(), Message: e.Message()}
+		if len(e.Fields()) > 0 {
+			xe.FieldNames = &xmlList{e.Fields()}
+			xe.Pointers = new(xmlList)
+			for _, p := range errorPaths(e) {
+				xe.Pointers.Items = append(xe.Pointers.Items, p.Pointer())
+			}
+		}
+		if d, ok := e.(DetailedError); ok {
+			var params []xmlParam
+			for name, v := range d.Params() {
+				params = append(params, xmlParam{name, fmt.Sprint(v)})
+			}
+			sort.Slice(params, func(i, j int) bool {
+				return params[i].Name < params[j].Name
+			})
+			if len(params) > 0 {
+				xe.Params = &xmlParams{params}
+			}
+			if v, ok := d.RejectedValue(); ok {
+				xe.Value = &v
+			}
+			xe.Redacted = d.Redacted()
+		}
+		if x.Errors == nil {
+			x.Errors = new(xmlErrors)
+		}
+		x.Errors.Errors = append(x.Errors.Errors, xe)
+	}
+
+	if _, err := fmt.Fprint(w, xml.Header); err != nil {
+		return err
+	}
+	return xml.NewEncoder(w).Encode(x)
+}
+
+func (p Problem) writeText(w http.ResponseWriter) error {
+	var b strings.Builder
+	b.WriteString(p.Title + "\n")
+	if p.Detail != "" {
+		b.WriteString(p.Detail + "\n")
+	}
+	for _, e := range p.Errors {
+		if len(e.Fields()) > 0 {
+			b.WriteString(strings.Join(e.Fields(), ", ") + ": ")
+		}
+		b.WriteString(e.Message() + "\n")
+	}
+	_, err := fmt.Fprint(w, b.String())
+	return err
+}
+
+var problemHTML = template.Must(template.New("problem").Parse(`<!DOCTYPE html>
+<html>
+<head><title>{{.Title}}</title></head>
+<body>
+<h1>{{.Title}}</h1>
+{{if .Detail}}<p>{{.Detail}}</p>
+{{end}}{{if .Errors}}<ul>
+{{range .Errors}}<li>{{range $i, $f := .Fields}}{{if $i}}, {{end}}<code>{{$f}}</code>{{end}}{{if .Fields}}: {{end}}{{.Message}}</li>
+{{end}}</ul>
+{{end}}</body>
+</html>
+`))
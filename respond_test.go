@@ -0,0 +1,96 @@
+package binding
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestWriteError(t *testing.T) {
+	Convey("Given errors of various kinds", t, func() {
+		var validation, contentType, tooLarge Errors
+		validation.Add([]string{"name"}, RequiredError, "Required")
+		contentType.Add([]string{}, ContentTypeError, "Unsupported Content-Type")
+		tooLarge.Add([]string{}, TooLargeError, "http: request body too large")
+
+		Convey("StatusCode should map their kinds to status codes", func() {
+			So(StatusCode(validation), ShouldEqual, StatusUnprocessableEntity)
+			So(StatusCode(contentType), ShouldEqual, http.StatusUnsupportedMediaType)
+			So(StatusCode(tooLarge), ShouldEqual, http.StatusRequestEntityTooLarge)
+			So(StatusCode(errors.New("db down")), ShouldEqual, http.StatusInternalServerError)
+		})
+
+		Convey("The mapping should be overridable", func() {
+			defer func() { delete(StatusCodes, RequiredError) }()
+			StatusCodes[RequiredError] = http.StatusBadRequest
+			So(StatusCode(validation), ShouldEqual, http.StatusBadRequest)
+		})
+
+		Convey("WriteError should negotiate the response format", func() {
+			for accept, contentType := range map[string]string{
+				"":                                    "application/problem+json",
+				"application/json":                    "application/json; charset=utf-8",
+				"text/html, */*;q=0.8":                "text/html; charset=utf-8",
+				"application/xml;q=0.9, text/*;q=0.5": "application/xml; charset=utf-8",
+				"text/*":                              "text/html; charset=utf-8",
+				"text/plain":                          "text/plain; charset=utf-8",
+				"image/png":                           "application/problem+json",
+			} {
+				req := httptest.NewRequest("POST", "http://www.example.com", nil)
+				req.Header.Set("Accept", accept)
+				w := httptest.NewRecorder()
+				So(WriteError(w, req, validation), ShouldBeNil)
+				So(w.Code, ShouldEqual, StatusUnprocessableEntity)
+				So(w.Header().Get("Content-Type"), ShouldEqual, contentType)
+				So(w.Body.String(), ShouldContainSubstring, "Required")
+			}
+		})
+
+		Convey("WriteError should advertise supported Content-Types for a 415", func() {
+			req := httptest.NewRequest("POST", "http://www.example.com", nil)
+			w := httptest.NewRecorder()
+			So(WriteError(w, req, contentType), ShouldBeNil)
+			So(w.Code, ShouldEqual, http.StatusUnsupportedMediaType)
+			So(w.Header().Get("Accept-Post"), ShouldContainSubstring, "application/json")
+		})
+
+		Convey("XML should leave out the lists of errors about the whole request", func() {
+			req := httptest.NewRequest("POST", "http://www.example.com", nil)
+			req.Header.Set("Accept", "application/xml")
+			w := httptest.NewRecorder()
+			So(WriteError(w, req, contentType), ShouldBeNil)
+			So(w.Body.String(), ShouldContainSubstring, "<classification>ContentTypeError</classification>")
+			So(w.Body.String(), ShouldNotContainSubstring, "<fieldNames>")
+			So(w.Body.String(), ShouldNotContainSubstring, "<pointers>")
+			So(w.Body.String(), ShouldNotContainSubstring, "<params>")
+
+			w = httptest.NewRecorder()
+			So(WriteError(w, req, validation), ShouldBeNil)
+			So(w.Body.String(), ShouldContainSubstring, "<fieldNames><i>name</i></fieldNames><pointers><i>/name</i></pointers>")
+		})
+
+		Convey("WriteError should not show the text of other errors", func() {
+			req := httptest.NewRequest("GET", "http://www.example.com", nil)
+			req.Header.Set("Accept", "text/plain")
+			w := httptest.NewRecorder()
+			So(WriteError(w, req, errors.New("db down")), ShouldBeNil)
+			So(w.Code, ShouldEqual, http.StatusInternalServerError)
+			So(w.Body.String(), ShouldNotContainSubstring, "db down")
+		})
+	})
+
+	Convey("Given a request body over the size limit", t, func() {
+		req := httptest.NewRequest("POST", "http://www.example.com", strings.NewReader(`{"email": "jane@example.com"}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Body = http.MaxBytesReader(httptest.NewRecorder(), req.Body, 8)
+
+		Convey("Binding should produce a TooLargeError", func() {
+			err := Bind(req, new(ContactModel))
+			So(StatusCode(err), ShouldEqual, http.StatusRequestEntityTooLarge)
+		})
+	})
+}
@@ -0,0 +1,205 @@
+package binding
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+type (
+	// A Condition is met when the field named Field is set, that is,
+	// it does not hold the zero value and, if Values is not empty, its
+	// value is one of Values (compared like Field.OneOf).
+	Condition struct {
+		Field  string
+		Values []string
+	}
+
+	// A Rule is a validation rule that spans several fields. Rules are
+	// put in a FieldMap as values; their keys only need to be unique:
+	//
+	//	"payment": binding.ExactlyOneOf("card_token", "iban"),
+	Rule struct {
+		kind   string
+		fields []string
+		groups []string
+	}
+)
+
+// MutuallyExclusive returns a Rule that allows at most one of the named
+// fields to be set. A violation produces an ExclusiveError.
+func MutuallyExclusive(fields ...string) Rule {
+	return Rule{kind: ExclusiveError, fields: fields}
+}
+
+// ExactlyOneOf returns a Rule that requires exactly one of the named
+// fields to be set. A violation produces an ExactlyOneError.
+func ExactlyOneOf(fields ...string) Rule {
+	return Rule{kind: ExactlyOneError, fields: fields}
+}
+
+// crossFields holds the fields of a FieldList by form name, so that
+// rules can look up the values of other fields.
+type crossFields map[string]interface{}
+
+func newCrossFields(fl FieldList) crossFields {
+	cf := make(crossFields)
+	for _, fieldSpec := range fl.fields() {
+		cf[fieldSpec.Form] = fieldSpec.Target
+	}
+	return cf
+}
+
+// isSet reports whether the named field holds a value other than the
+// zero value of its type.
+func (cf crossFields) isSet(name string) bool {
+	fieldPointer, ok := cf[name]
+	if !ok {
+		return false
+	}
+	for _, val := range fieldPointerValues(fieldPointer) {
+		if !isZeroValue(val) {
+			return true
+		}
+	}
+	return false
+}
+
+// meets reports whether cond is met.
+func (cf crossFields) meets(cond Condition) bool {
+	if !cf.isSet(cond.Field) {
+		return false
+	}
+	if len(cond.Values) == 0 {
+		return true
+	}
+	f := Field{OneOf: cond.Values}
+	for _, val := range fieldPointerValues(cf[cond.Field]) {
+		if str, ok := f.valueString(val); ok && f.isOneOf(str) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkCrossField checks the rules of fieldSpec that involve other fields.
+func checkCrossField(fieldPointer interface{}, fieldSpec Field, cf crossFields) Errors {
+	var errs Errors
+
+	addError := func(other, kind, key, message string, args ...interface{}) {
+		message = fmt.Sprintf(message, args...)
+		fieldSpec.addError(&errs, []string{fieldSpec.Form, other}, kind, key, message,
+			map[string]interface{}{"other": other})
+	}
+
+	set := cf.isSet(fieldSpec.Form)
+
+	if !set {
+		if c := fieldSpec.RequiredIf; c.Field != "" && cf.meets(c) {
+			addError(c.Field, RequiredError, "RequiredIf", "Required when %s is set", c.Field)
+		}
+		if c := fieldSpec.RequiredUnless; c.Field != "" && !cf.meets(c) {
+			addError(c.Field, RequiredError, "RequiredUnless", "Required unless %s is set", c.Field)
+		}
+		for _, other := range fieldSpec.RequiredWith {
+			if cf.isSet(other) {
+				addError(other, RequiredError, "RequiredIf", "Required when %s is set", other)
+				break
+			}
+		}
+		return errs
+	}
+
+	if other := fieldSpec.EqualTo; other != "" {
+		if !valuesEqual(fieldPointerValues(fieldPointer), fieldPointerValues(cf[other])) {
+			addError(other, MismatchError, MismatchError, "Must be equal to %s", other)
+		}
+	}
+	if other := fieldSpec.After; other != "" && cf.isSet(other) {
+		if c, ok := compareFields(fieldPointer, cf[other]); ok && c <= 0 {
+			addError(other, OrderError, "After", "Must be after %s", other)
+		}
+	}
+	if other := fieldSpec.Before; other != "" && cf.isSet(other) {
+		if c, ok := compareFields(fieldPointer, cf[other]); ok && c >= 0 {
+			addError(other, OrderError, "Before", "Must be before %s", other)
+		}
+	}
+
+	return errs
+}
+
+// check checks r against the fields of a FieldMap.
+func (r Rule) check(cf crossFields) Errors {
+	var errs Errors
+
+	var set []string
+	for _, name := range r.fields {
+		if cf.isSet(name) {
+			set = append(set, name)
+		}
+	}
+
+	names := strings.Join(r.fields, ", ")
+	params := map[string]interface{}{"fields": names}
+	switch r.kind {
+	case ExclusiveError:
+		if len(set) > 1 {
+			errs.addTemplate(r.fields, r.kind, r.kind, "Only one of "+names+" may be set", params)
+		}
+	case ExactlyOneError:
+		if len(set) != 1 {
+			errs.addTemplate(r.fields, r.kind, r.kind, "Exactly one of "+names+" must be set", params)
+		}
+	}
+
+	return errs
+}
+
+// fieldPointerValues is like fieldValues but only returns the values.
+func fieldPointerValues(fieldPointer interface{}) []interface{} {
+	vals, _ := fieldValues(fieldPointer)
+	return vals
+}
+
+func isZeroValue(val interface{}) bool {
+	switch v := val.(type) {
+	case time.Time:
+		return v.IsZero()
+	case string:
+		return v == ""
+	case bool:
+		return !v
+	}
+	if f, ok := bigFloat(val); ok {
+		return f.Sign() == 0
+	}
+	return val == nil
+}
+
+func valuesEqual(a, b []interface{}) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if t, ok := a[i].(time.Time); ok {
+			if u, ok := b[i].(time.Time); !ok || !t.Equal(u) {
+				return false
+			}
+			continue
+		}
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// compareFields compares the first values of two fields.
+func compareFields(a, b interface{}) (int, bool) {
+	av, bv := fieldPointerValues(a), fieldPointerValues(b)
+	if len(av) == 0 || len(bv) == 0 {
+		return 0, false
+	}
+	return compare(av[0], bv[0])
+}
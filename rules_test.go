@@ -0,0 +1,83 @@
+package binding
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type SignupModel struct {
+	Password        string
+	PasswordConfirm string
+	StartDate       time.Time
+	EndDate         time.Time
+	Country         string
+	VATID           string
+	CardToken       string
+	IBAN            string
+}
+
+func (m *SignupModel) FieldMap(req *http.Request) FieldMap {
+	return FieldMap{
+		&m.Password:        "password",
+		&m.PasswordConfirm: Field{Form: "password_confirm", EqualTo: "password"},
+		&m.StartDate:       "start_date",
+		&m.EndDate:         Field{Form: "end_date", After: "start_date"},
+		&m.Country:         "country",
+		&m.VATID: Field{
+			Form:       "vat_id",
+			RequiredIf: Condition{Field: "country", Values: []string{"DE", "FR", "IT"}},
+		},
+		&m.CardToken: "card_token",
+		&m.IBAN:      "iban",
+		"payment":    ExactlyOneOf("card_token", "iban"),
+	}
+}
+
+func TestCrossFieldRules(t *testing.T) {
+	Convey("Given a struct with cross-field rules", t, func() {
+		req, err := http.NewRequest("POST", "http://www.example.com", nil)
+		So(err, ShouldBeNil)
+		model := new(SignupModel)
+
+		Convey("Values that satisfy the rules should produce no errors", func() {
+			errs := bindForm(req, model, map[string][]string{
+				"password":         {"secret"},
+				"password_confirm": {"secret"},
+				"start_date":       {"2020-01-01T00:00:00Z"},
+				"end_date":         {"2020-01-02T00:00:00Z"},
+				"country":          {"US"},
+				"iban":             {"DE89370400440532013000"},
+			}, nil)
+			So(errs, ShouldBeEmpty)
+		})
+
+		Convey("Values that break the rules should produce errors naming every field involved", func() {
+			errs := bindForm(req, model, map[string][]string{
+				"password":         {"secret"},
+				"password_confirm": {"secre"},
+				"start_date":       {"2020-01-02T00:00:00Z"},
+				"end_date":         {"2020-01-01T00:00:00Z"},
+				"country":          {"DE"},
+				"card_token":       {"tok"},
+				"iban":             {"DE89370400440532013000"},
+			}, nil)
+
+			found := make(map[string][]string)
+			for _, e := range errs {
+				found[e.Kind()] = e.Fields()
+			}
+			So(found[MismatchError], ShouldResemble, []string{"password_confirm", "password"})
+			So(found[OrderError], ShouldResemble, []string{"end_date", "start_date"})
+			So(found[RequiredError], ShouldResemble, []string{"vat_id", "country"})
+			So(found[ExactlyOneError], ShouldResemble, []string{"card_token", "iban"})
+		})
+
+		Convey("ExactlyOneOf should fail when none of the fields is set", func() {
+			errs := bindForm(req, model, map[string][]string{}, nil)
+			So(errs.Has(ExactlyOneError), ShouldBeTrue)
+		})
+	})
+}
@@ -0,0 +1,174 @@
+package binding
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Strict makes binding report every key in the request that is not in
+// the FieldMap as an UnknownFieldError, suggesting the closest mapped
+// name when there is one. Keys that match one of the allow patterns,
+// such as "utm_*", are ignored; see path.Match for the syntax. For JSON
+// requests, keys of nested objects must match the fields of the Go
+// types being decoded into, as with json.Decoder.DisallowUnknownFields.
+//
+// Strict also makes fields that hold a single value reject repeated
+// keys, unless their MultiValue policy says otherwise.
+func Strict(allow ...string) Option {
+	return func(o *options) {
+		o.strict = true
+		o.allow = append(o.allow, allow...)
+	}
+}
+
+// allowed reports whether key matches one of the allow patterns.
+func (st *bindState) allowed(key string) bool {
+	for _, pattern := range st.allow {
+		if ok, _ := path.Match(pattern, key); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// unknownFields returns an UnknownFieldError for each of keys that is
+// not declared in fl nor allowed, in sorted order.
+func (st *bindState) unknownFields(keys []string, fl FieldList) Errors {
+	var errs Errors
+
+	var names []string
+	for _, fieldSpec := range fl.fields() {
+		names = append(names, fieldSpec.Form)
+	}
+	sort.Strings(names)
+
+	sort.Strings(keys)
+	for i, key := range keys {
+		if (i > 0 && keys[i-1] == key) || st.allowed(key) || containsString(names, key) {
+			continue
+		}
+		message := "Unknown field"
+		params := map[string]interface{}{"field": key}
+		if suggestion := closest(key, names); suggestion != "" {
+			message = fmt.Sprintf("Unknown field; did you mean %q?", suggestion)
+			params["suggestion"] = suggestion
+		}
+		errs.addTemplate([]string{key}, UnknownFieldError, UnknownFieldError, message, params)
+	}
+
+	return errs
+}
+
+// strictJSON reports the top-level keys of body that are not decoded
+// into a field of fl, as given by names, and removes them, along with
+// the allowed ones, so that the rest of body can be decoded with
+// DisallowUnknownFields.
+func (st *bindState) strictJSON(body []byte, fl FieldList, names jsonKeys) ([]byte, Errors) {
+	var obj map[string]json.RawMessage
+	if json.Unmarshal(body, &obj) != nil {
+		return body, nil
+	}
+
+	var keys []string
+	for key := range obj {
+		if _, ok := names.form(key); !ok {
+			keys = append(keys, key)
+		}
+	}
+	errs := st.unknownFields(keys, fl)
+
+	stripped := false
+	for _, key := range keys {
+		if st.allowed(key) || errs.hasField(key) {
+			delete(obj, key)
+			stripped = true
+		}
+	}
+	if stripped {
+		body, _ = json.Marshal(obj)
+	}
+
+	return body, errs
+}
+
+// unknownJSONField returns the UnknownFieldError for err if it is the
+// error json.Decoder reports for an unknown field.
+func unknownJSONField(err error) (Error, bool) {
+	const prefix = "json: unknown field "
+	if !strings.HasPrefix(err.Error(), prefix) {
+		return nil, false
+	}
+	name, uerr := strconv.Unquote(strings.TrimPrefix(err.Error(), prefix))
+	if uerr != nil {
+		return nil, false
+	}
+	return fieldsError{
+		fields:  []string{name},
+		kind:    UnknownFieldError,
+		message: "Unknown field",
+		key:     UnknownFieldError,
+		params:  map[string]interface{}{"field": name},
+	}, true
+}
+
+// closest returns the name closest to key by edit distance, if it is
+// close enough to be a likely typo.
+func closest(key string, names []string) string {
+	best, bestDist := "", -1
+	for _, name := range names {
+		d := editDistance(key, name)
+		if bestDist < 0 || d < bestDist {
+			best, bestDist = name, d
+		}
+	}
+
+	limit := len([]rune(key)) / 3
+	if limit < 2 {
+		limit = 2
+	}
+	if bestDist < 0 || bestDist > limit {
+		return ""
+	}
+	return best
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = minInt(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(rb)]
+}
+
+func minInt(first int, rest ...int) int {
+	for _, n := range rest {
+		if n < first {
+			first = n
+		}
+	}
+	return first
+}
+
+// containsString reports whether the sorted strs contains s.
+func containsString(strs []string, s string) bool {
+	i := sort.SearchStrings(strs, s)
+	return i < len(strs) && strs[i] == s
+}
@@ -0,0 +1,102 @@
+package binding
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type ContactModel struct {
+	Email   string        `json:"email"`
+	Name    string        `json:"name"`
+	Address *AddressModel `json:"address"`
+}
+
+func (m *ContactModel) FieldMap(req *http.Request) FieldMap {
+	return FieldMap{
+		&m.Email:   "email",
+		&m.Name:    "name",
+		&m.Address: "address",
+	}
+}
+
+func TestStrict(t *testing.T) {
+	Convey("Given a request with unknown keys", t, func() {
+		model := new(ContactModel)
+
+		Convey("Strict form binding should report each of them", func() {
+			query := url.Values{
+				"emial":      {"jane@example.com"},
+				"name":       {"Jane"},
+				"zzzzzz":     {"1"},
+				"utm_source": {"newsletter"},
+			}
+			req, err := http.NewRequest("GET", "http://www.example.com/?"+query.Encode(), nil)
+			So(err, ShouldBeNil)
+
+			err = Bind(req, model, Strict("utm_*"))
+			errs, _ := err.(Errors)
+			So(errs.Len(), ShouldEqual, 2)
+			So(errs[0].Fields(), ShouldResemble, []string{"emial"})
+			So(errs[0].Kind(), ShouldEqual, UnknownFieldError)
+			So(errs[0].Message(), ShouldEqual, `Unknown field; did you mean "email"?`)
+			So(errs[1].Fields(), ShouldResemble, []string{"zzzzzz"})
+			So(errs[1].Message(), ShouldEqual, "Unknown field")
+			So(model.Name, ShouldEqual, "Jane")
+		})
+
+		Convey("Without Strict they should be ignored", func() {
+			req, err := http.NewRequest("GET", "http://www.example.com/?emial=x", nil)
+			So(err, ShouldBeNil)
+			So(Bind(req, model), ShouldBeNil)
+		})
+
+		Convey("Strict JSON binding should report unknown keys at the top level", func() {
+			data := `{"emial": "jane@example.com", "name": "Jane", "utm_medium": "email"}`
+			req, err := http.NewRequest("POST", "http://www.example.com", strings.NewReader(data))
+			So(err, ShouldBeNil)
+			req.Header.Set("Content-Type", "application/json")
+
+			err = Bind(req, model, Strict("utm_*"))
+			errs, _ := err.(Errors)
+			So(errs.Len(), ShouldEqual, 1)
+			So(errs[0].Fields(), ShouldResemble, []string{"emial"})
+			So(model.Name, ShouldEqual, "Jane")
+		})
+
+		Convey("Strict JSON binding should report unknown keys in nested objects", func() {
+			data := `{"address": {"zip": "12345", "cuntry": "DE"}}`
+			req, err := http.NewRequest("POST", "http://www.example.com", strings.NewReader(data))
+			So(err, ShouldBeNil)
+			req.Header.Set("Content-Type", "application/json")
+
+			err = Bind(req, model, Strict())
+			errs, _ := err.(Errors)
+			So(errs.Len(), ShouldEqual, 1)
+			So(errs[0].Kind(), ShouldEqual, UnknownFieldError)
+			So(errs[0].Fields(), ShouldResemble, []string{"cuntry"})
+		})
+
+		Convey("Strict JSON binding should accept keys that differ from the form names only in case", func() {
+			req, err := http.NewRequest("POST", "http://www.example.com", strings.NewReader(`{"Page": 7, "LIMIT": 5}`))
+			So(err, ShouldBeNil)
+			req.Header.Set("Content-Type", "application/json")
+
+			params := new(ListParams)
+			So(Bind(req, params, Strict()), ShouldBeNil)
+			So(params.Page, ShouldEqual, 7)
+			So(params.Limit, ShouldEqual, 5)
+		})
+	})
+
+	Convey("Given two strings", t, func() {
+		Convey("Their edit distance should be computed", func() {
+			So(editDistance("emial", "email"), ShouldEqual, 2)
+			So(editDistance("", "abc"), ShouldEqual, 3)
+			So(editDistance("kitten", "sitting"), ShouldEqual, 3)
+		})
+	})
+}
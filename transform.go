@@ -0,0 +1,135 @@
+package binding
+
+import (
+	"html"
+	"strings"
+	"unicode"
+)
+
+// TrimSpace removes leading and trailing white space.
+func TrimSpace(s string) string {
+	return strings.TrimSpace(s)
+}
+
+// CollapseSpace trims s and replaces each run of white space inside it
+// with a single space.
+func CollapseSpace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// Lower maps s to lower case, e.g. for email addresses.
+func Lower(s string) string {
+	return strings.ToLower(s)
+}
+
+// Upper maps s to upper case, e.g. for country codes.
+func Upper(s string) string {
+	return strings.ToUpper(s)
+}
+
+// StripControl removes control characters other than tab and newline.
+func StripControl(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) && r != '\t' && r != '\n' {
+			return -1
+		}
+		return r
+	}, s)
+}
+
+// StripTags removes HTML tags, comments and declarations, that is a <
+// followed by a letter, /, ! or ? up to the next >, leaving the text
+// between them. Any other < is kept, as in "price < 5". It is not a
+// sanitizer: use EscapeHTML as well, or instead, if the result is
+// rendered as HTML.
+func StripTags(s string) string {
+	var b strings.Builder
+	for {
+		i := strings.IndexByte(s, '<')
+		if i < 0 || i+1 == len(s) {
+			break
+		}
+		if c := s[i+1]; !isLetter(c) && c != '/' && c != '!' && c != '?' {
+			b.WriteString(s[:i+1])
+			s = s[i+1:]
+			continue
+		}
+		end := strings.IndexByte(s[i:], '>')
+		if end < 0 {
+			break
+		}
+		b.WriteString(s[:i])
+		s = s[i+end+1:]
+	}
+	b.WriteString(s)
+	return b.String()
+}
+
+func isLetter(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
+
+// EscapeHTML escapes the characters <, >, &, ' and ".
+func EscapeHTML(s string) string {
+	return html.EscapeString(s)
+}
+
+// transform applies the Transforms of f to a copy of strs.
+func (f Field) transform(strs []string) []string {
+	if len(f.Transforms) == 0 || len(strs) == 0 {
+		return strs
+	}
+	out := make([]string, len(strs))
+	for i, s := range strs {
+		for _, fn := range f.Transforms {
+			s = fn(s)
+		}
+		out[i] = s
+	}
+	return out
+}
+
+// transformValue applies the Transforms of f to the value of a string
+// field that was not bound from raw strings, such as a JSON field.
+func (f Field) transformValue(fieldPointer interface{}) {
+	if opt, ok := fieldPointer.(optionalValue); ok {
+		fieldPointer = opt.valuePointer()
+	}
+
+	switch t := fieldPointer.(type) {
+	case *string:
+		*t = f.transform([]string{*t})[0]
+	case **string:
+		if *t != nil {
+			s := f.transform([]string{**t})[0]
+			*t = &s
+		}
+	case *[]string:
+		*t = f.transform(*t)
+	}
+}
+
+// normalize runs the Normalize hooks of the fields of fl that were
+// present in the request and bound without errors. If decoded is true,
+// the fields were decoded from a body such as JSON rather than from raw
+// strings, so their Transforms are applied first.
+func normalize(errs Errors, st *bindState, fl FieldList, decoded bool) Errors {
+	for _, fieldSpec := range fl.fields() {
+		if !st.fields.Has(fieldSpec.Form) || errs.hasField(fieldSpec.Form) {
+			continue
+		}
+
+		if decoded {
+			fieldSpec.transformValue(fieldSpec.Target)
+		}
+
+		if fieldSpec.Normalize == nil {
+			continue
+		}
+		err := fieldSpec.Normalize()
+		if err != nil {
+			errs.addErr([]string{fieldSpec.Form}, err)
+		}
+	}
+	return errs
+}
@@ -0,0 +1,81 @@
+package binding
+
+import (
+	"math"
+	"net/http"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type ProfileModel struct {
+	Email string
+	Name  *string
+	Bio   Optional[string]
+	Tags  []string
+	Price float64
+}
+
+func (m *ProfileModel) FieldMap(req *http.Request) FieldMap {
+	return FieldMap{
+		&m.Email: Field{Form: "email", Transforms: []func(string) string{TrimSpace, Lower}, Format: FormatEmail},
+		&m.Name:  Field{Form: "name", Transforms: []func(string) string{StripControl, CollapseSpace}},
+		&m.Bio:   Field{Form: "bio", Transforms: []func(string) string{StripTags, TrimSpace}},
+		&m.Tags:  Field{Form: "tags", Transforms: []func(string) string{Upper}},
+		&m.Price: Field{Form: "price", Transforms: []func(string) string{TrimSpace}, Normalize: func() error {
+			m.Price = math.Round(m.Price*100) / 100
+			return nil
+		}},
+	}
+}
+
+func TestTransforms(t *testing.T) {
+	Convey("Given the built-in transforms", t, func() {
+		So(CollapseSpace("  a \t b\n\nc "), ShouldEqual, "a b c")
+		So(StripControl("a\x00b\tc\x1b\n"), ShouldEqual, "ab\tc\n")
+		So(StripTags(`<p class="x">Hi <b>there</b></p>`), ShouldEqual, "Hi there")
+		So(StripTags("price < 5"), ShouldEqual, "price < 5")
+		So(StripTags("a < b and c > d"), ShouldEqual, "a < b and c > d")
+		So(StripTags("a<3 <!-- x --><br/>b <i"), ShouldEqual, "a<3 b <i")
+		So(EscapeHTML(`<a href="x">&</a>`), ShouldEqual, "&lt;a href=&#34;x&#34;&gt;&amp;&lt;/a&gt;")
+	})
+
+	Convey("Given a struct with transforms and a Normalize hook", t, func() {
+		model := new(ProfileModel)
+
+		Convey("Form values should be transformed before conversion and validation", func() {
+			req, err := http.NewRequest("POST", "http://www.example.com", nil)
+			So(err, ShouldBeNil)
+			form := map[string][]string{
+				"email": {"  Jane@Example.COM "},
+				"name":  {" Jane\x00   Doe "},
+				"bio":   {" <i>Hello</i> "},
+				"tags":  {"a", "b"},
+				"price": {" 9.999 "},
+			}
+
+			errs := bindForm(req, model, form, nil)
+			So(errs, ShouldBeEmpty)
+			So(model.Email, ShouldEqual, "jane@example.com")
+			So(*model.Name, ShouldEqual, "Jane Doe")
+			So(model.Bio.Value, ShouldEqual, "Hello")
+			So(model.Tags, ShouldResemble, []string{"A", "B"})
+			So(model.Price, ShouldEqual, 10)
+			So(form["email"], ShouldResemble, []string{"  Jane@Example.COM "})
+		})
+
+		Convey("JSON string fields should be transformed after decoding", func() {
+			data := `{"email": " JANE@example.com", "name": "Jane \u0007 Doe", "bio": "<b>Hi</b>", "price": 1.005}`
+			req, err := http.NewRequest("POST", "http://www.example.com", strings.NewReader(data))
+			So(err, ShouldBeNil)
+			req.Header.Set("Content-Type", "application/json")
+
+			So(Bind(req, model), ShouldBeNil)
+			So(model.Email, ShouldEqual, "jane@example.com")
+			So(*model.Name, ShouldEqual, "Jane Doe")
+			So(model.Bio.Value, ShouldEqual, "Hi")
+			So(model.Price, ShouldEqual, 1)
+		})
+	})
+}
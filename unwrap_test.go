@@ -0,0 +1,115 @@
+package binding
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+var errInvalidCoupon = errors.New("invalid coupon")
+
+type CouponForm struct {
+	Code    string
+	Count   int
+	Expires time.Time
+}
+
+func (f *CouponForm) FieldMap(req *http.Request) FieldMap {
+	return FieldMap{
+		&f.Code:    Field{Form: "code", Required: true},
+		&f.Count:   "count",
+		&f.Expires: "expires",
+	}
+}
+
+func (f *CouponForm) Validate(req *http.Request) error {
+	if f.Code == "expired" {
+		return errInvalidCoupon
+	}
+	return nil
+}
+
+func TestUnwrap(t *testing.T) {
+	Convey("Given a request with invalid values", t, func() {
+		bind := func(query string) error {
+			req, err := http.NewRequest("GET", "http://www.example.com/?"+query, nil)
+			So(err, ShouldBeNil)
+			return Bind(req, new(CouponForm))
+		}
+
+		Convey("The errors should match the sentinels of their kinds", func() {
+			err := bind("count=x")
+			So(errors.Is(err, ErrRequired), ShouldBeTrue)
+			So(errors.Is(err, ErrType), ShouldBeTrue)
+			So(errors.Is(err, ErrRange), ShouldBeFalse)
+		})
+
+		Convey("A conversion error should keep its cause", func() {
+			err := bind("code=a&count=x")
+			var numErr *strconv.NumError
+			So(errors.As(err, &numErr), ShouldBeTrue)
+			So(numErr.Num, ShouldEqual, "x")
+			So(errors.Is(err, strconv.ErrSyntax), ShouldBeTrue)
+		})
+
+		Convey("An out-of-range number should wrap strconv.ErrRange", func() {
+			err := bind("code=a&count=99999999999999999999")
+			So(errors.Is(err, ErrRange), ShouldBeTrue)
+			So(errors.Is(err, strconv.ErrRange), ShouldBeTrue)
+		})
+
+		Convey("A time should keep its *time.ParseError", func() {
+			var parseErr *time.ParseError
+			So(errors.As(bind("code=a&expires=soon"), &parseErr), ShouldBeTrue)
+			So(parseErr.Value, ShouldEqual, "soon")
+		})
+
+		Convey("An error returned by Validate should be kept", func() {
+			err := bind("code=expired")
+			So(errors.Is(err, errInvalidCoupon), ShouldBeTrue)
+		})
+
+		Convey("Errors should unwrap to each of its errors", func() {
+			errs := bind("count=x").(Errors)
+			So(errs.Unwrap(), ShouldHaveLength, 2)
+			var e Error
+			So(errors.As(errs, &e), ShouldBeTrue)
+			So(e.Kind(), ShouldEqual, RequiredError)
+		})
+	})
+
+	Convey("Given a JSON request that cannot be decoded", t, func() {
+		req, err := http.NewRequest("POST", "http://www.example.com", strings.NewReader(`{"count": "x"}`))
+		So(err, ShouldBeNil)
+		req.Header.Set("Content-Type", "application/json")
+		err = Bind(req, new(CouponForm))
+
+		Convey("The error should keep the error of encoding/json", func() {
+			So(errors.Is(err, ErrType), ShouldBeTrue)
+			var typeErr *json.UnmarshalTypeError
+			So(errors.As(err, &typeErr), ShouldBeTrue)
+			So(typeErr.Value, ShouldEqual, "string")
+		})
+	})
+
+	Convey("Given a canceled request", t, func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		err := runValidators(ctx, []asyncCheck{{form: "code", validate: func(ctx context.Context) error {
+			<-ctx.Done()
+			return nil
+		}}}, 1)
+
+		Convey("The error should wrap the error of the context", func() {
+			So(errors.Is(err, ErrCanceled), ShouldBeTrue)
+			So(errors.Is(err, context.Canceled), ShouldBeTrue)
+		})
+	})
+}